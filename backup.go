@@ -73,6 +73,117 @@ func (ro *RestoreOptions) Destroy() {
 	C.rocksdb_restore_options_destroy(ro.c)
 }
 
+// BackupEngineOptions captures the options of a backup engine opened with
+// OpenBackupEngineWithOptions.
+type BackupEngineOptions struct {
+	c *C.rocksdb_backup_engine_options_t
+
+	backupDir string
+}
+
+// NewBackupEngineOptions creates a BackupEngineOptions instance for backups
+// stored in backupDir.
+func NewBackupEngineOptions(backupDir string) *BackupEngineOptions {
+	cDir := C.CString(backupDir)
+	defer C.free(unsafe.Pointer(cDir))
+	return &BackupEngineOptions{
+		c:         C.rocksdb_backup_engine_options_create(cDir),
+		backupDir: backupDir,
+	}
+}
+
+// SetShareTableFiles sets whether table files are shared between backups,
+// so that only new files are copied.
+// Default: true
+func (opts *BackupEngineOptions) SetShareTableFiles(value bool) {
+	C.rocksdb_backup_engine_options_set_share_table_files(opts.c, boolToChar(value))
+}
+
+// GetShareTableFiles returns whether table files are shared between
+// backups.
+func (opts *BackupEngineOptions) GetShareTableFiles() bool {
+	return charToBool(C.rocksdb_backup_engine_options_get_share_table_files(opts.c))
+}
+
+// SetSync sets whether the backup files are synced to disk, so that a
+// backup stays consistent after a machine crash.
+// Default: true
+func (opts *BackupEngineOptions) SetSync(value bool) {
+	C.rocksdb_backup_engine_options_set_sync(opts.c, boolToChar(value))
+}
+
+// GetSync returns whether the backup files are synced to disk.
+func (opts *BackupEngineOptions) GetSync() bool {
+	return charToBool(C.rocksdb_backup_engine_options_get_sync(opts.c))
+}
+
+// SetDestroyOldData sets whether the existing backups are deleted when the
+// backup engine is opened.
+// Default: false
+func (opts *BackupEngineOptions) SetDestroyOldData(value bool) {
+	C.rocksdb_backup_engine_options_set_destroy_old_data(opts.c, boolToChar(value))
+}
+
+// GetDestroyOldData returns whether the existing backups are deleted when
+// the backup engine is opened.
+func (opts *BackupEngineOptions) GetDestroyOldData() bool {
+	return charToBool(C.rocksdb_backup_engine_options_get_destroy_old_data(opts.c))
+}
+
+// SetBackupLogFiles sets whether the write ahead logs are backed up. If
+// false, the database must be flushed before a backup.
+// Default: true
+func (opts *BackupEngineOptions) SetBackupLogFiles(value bool) {
+	C.rocksdb_backup_engine_options_set_backup_log_files(opts.c, boolToChar(value))
+}
+
+// GetBackupLogFiles returns whether the write ahead logs are backed up.
+func (opts *BackupEngineOptions) GetBackupLogFiles() bool {
+	return charToBool(C.rocksdb_backup_engine_options_get_backup_log_files(opts.c))
+}
+
+// SetBackupRateLimit limits the bytes per second written by backups.
+// The backup engine creates its own rate limiter; the C API of RocksDB
+// cannot share a RateLimiter with it.
+// Default: 0, unlimited
+func (opts *BackupEngineOptions) SetBackupRateLimit(bytesPerSec uint64) {
+	C.rocksdb_backup_engine_options_set_backup_rate_limit(opts.c, C.uint64_t(bytesPerSec))
+}
+
+// GetBackupRateLimit returns the bytes per second written by backups.
+func (opts *BackupEngineOptions) GetBackupRateLimit() uint64 {
+	return uint64(C.rocksdb_backup_engine_options_get_backup_rate_limit(opts.c))
+}
+
+// SetRestoreRateLimit limits the bytes per second written by restores.
+// Default: 0, unlimited
+func (opts *BackupEngineOptions) SetRestoreRateLimit(bytesPerSec uint64) {
+	C.rocksdb_backup_engine_options_set_restore_rate_limit(opts.c, C.uint64_t(bytesPerSec))
+}
+
+// GetRestoreRateLimit returns the bytes per second written by restores.
+func (opts *BackupEngineOptions) GetRestoreRateLimit() uint64 {
+	return uint64(C.rocksdb_backup_engine_options_get_restore_rate_limit(opts.c))
+}
+
+// SetMaxBackgroundOperations sets the number of files copied in parallel.
+// Default: 1
+func (opts *BackupEngineOptions) SetMaxBackgroundOperations(value int) {
+	C.rocksdb_backup_engine_options_set_max_background_operations(opts.c, C.int(value))
+}
+
+// GetMaxBackgroundOperations returns the number of files copied in
+// parallel.
+func (opts *BackupEngineOptions) GetMaxBackgroundOperations() int {
+	return int(C.rocksdb_backup_engine_options_get_max_background_operations(opts.c))
+}
+
+// Destroy destroys this BackupEngineOptions instance.
+func (opts *BackupEngineOptions) Destroy() {
+	C.rocksdb_backup_engine_options_destroy(opts.c)
+	opts.c = nil
+}
+
 // BackupEngine is a reusable handle to a RocksDB Backup, created by
 // OpenBackupEngine.
 type BackupEngine struct {
@@ -99,6 +210,27 @@ func OpenBackupEngine(opts *Options, path string) (*BackupEngine, error) {
 	}, nil
 }
 
+// OpenBackupEngineWithOptions opens a backup engine with the given backup
+// engine options. env is the environment of the databases which are backed
+// up, nil for the default environment.
+func OpenBackupEngineWithOptions(opts *BackupEngineOptions, env *Env) (*BackupEngine, error) {
+	var cErr *C.char
+	if env == nil {
+		env = NewDefaultEnv()
+		defer env.Destroy()
+	}
+
+	be := C.rocksdb_backup_engine_open_opts(opts.c, env.c, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, errors.New(C.GoString(cErr))
+	}
+	return &BackupEngine{
+		c:    be,
+		path: opts.backupDir,
+	}, nil
+}
+
 // UnsafeGetBackupEngine returns the underlying c backup engine.
 func (b *BackupEngine) UnsafeGetBackupEngine() unsafe.Pointer {
 	return unsafe.Pointer(b.c)
@@ -111,7 +243,7 @@ func (b *BackupEngine) CreateNewBackup(db *DB) error {
 	C.rocksdb_backup_engine_create_new_backup(b.c, db.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 
 	return nil
@@ -139,7 +271,7 @@ func (b *BackupEngine) RestoreDBFromLatestBackup(dbDir, walDir string, ro *Resto
 	C.rocksdb_backup_engine_restore_db_from_latest_backup(b.c, cDbDir, cWalDir, ro.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
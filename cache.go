@@ -4,6 +4,9 @@ package gorocksdb
 import "C"
 
 // Cache is a cache used to store data read from data in memory.
+//
+// A Cache can be shared by several databases, its methods are safe for
+// concurrent use.
 type Cache struct {
 	c *C.rocksdb_cache_t
 }
@@ -13,13 +16,140 @@ func NewLRUCache(capacity int) *Cache {
 	return NewNativeCache(C.rocksdb_cache_create_lru(C.size_t(capacity)))
 }
 
+// NewLRUCacheWithOptions creates a new LRU Cache object with the given
+// options.
+func NewLRUCacheWithOptions(opts *LRUCacheOptions) *Cache {
+	return NewNativeCache(C.rocksdb_cache_create_lru_opts(opts.c))
+}
+
+// NewLRUCacheWithStrictCapacityLimit creates a new LRU Cache object with
+// the capacity given. Inserts fail when the cache is full and its entries
+// are in use, instead of exceeding the capacity. Reads of blocks which
+// cannot be cached then fail with an Incomplete error.
+func NewLRUCacheWithStrictCapacityLimit(capacity int) *Cache {
+	return NewNativeCache(C.rocksdb_cache_create_lru_with_strict_capacity_limit(C.size_t(capacity)))
+}
+
+// NewHyperClockCache creates a new HyperClockCache object with the
+// capacity given. It scales better with many threads than a LRU cache.
+// estimatedEntryCharge is the expected average size of an entry, e.g. the
+// block size; 0 lets the cache size its table dynamically.
+func NewHyperClockCache(capacity, estimatedEntryCharge int) *Cache {
+	return NewNativeCache(C.rocksdb_cache_create_hyper_clock(C.size_t(capacity), C.size_t(estimatedEntryCharge)))
+}
+
+// NewHyperClockCacheWithOptions creates a new HyperClockCache object with
+// the given options.
+func NewHyperClockCacheWithOptions(opts *HyperClockCacheOptions) *Cache {
+	return NewNativeCache(C.rocksdb_cache_create_hyper_clock_opts(opts.c))
+}
+
 // NewNativeCache creates a Cache object.
 func NewNativeCache(c *C.rocksdb_cache_t) *Cache {
 	return &Cache{c}
 }
 
+// SetCapacity sets the capacity of the cache. If the new capacity is
+// lower than the usage, entries are evicted until it fits, as far as they
+// are not in use.
+func (c *Cache) SetCapacity(capacity int) {
+	C.rocksdb_cache_set_capacity(c.c, C.size_t(capacity))
+}
+
+// GetCapacity returns the capacity of the cache.
+func (c *Cache) GetCapacity() int {
+	return int(C.rocksdb_cache_get_capacity(c.c))
+}
+
+// GetUsage returns the memory size of the entries in the cache.
+func (c *Cache) GetUsage() int {
+	return int(C.rocksdb_cache_get_usage(c.c))
+}
+
+// GetPinnedUsage returns the memory size of the entries which are in use.
+func (c *Cache) GetPinnedUsage() int {
+	return int(C.rocksdb_cache_get_pinned_usage(c.c))
+}
+
 // Destroy deallocates the Cache object.
 func (c *Cache) Destroy() {
 	C.rocksdb_cache_destroy(c.c)
 	c.c = nil
 }
+
+// LRUCacheOptions represent the options of a LRU cache.
+//
+// The C API of RocksDB has no setters for the strict capacity limit and the
+// high priority pool ratio. Use NewLRUCacheWithStrictCapacityLimit for a
+// strict capacity limit.
+type LRUCacheOptions struct {
+	c *C.rocksdb_lru_cache_options_t
+}
+
+// NewDefaultLRUCacheOptions creates a default LRUCacheOptions object.
+func NewDefaultLRUCacheOptions() *LRUCacheOptions {
+	return NewNativeLRUCacheOptions(C.rocksdb_lru_cache_options_create())
+}
+
+// NewNativeLRUCacheOptions creates a LRUCacheOptions object.
+func NewNativeLRUCacheOptions(c *C.rocksdb_lru_cache_options_t) *LRUCacheOptions {
+	return &LRUCacheOptions{c}
+}
+
+// SetCapacity sets the capacity of the cache.
+func (opts *LRUCacheOptions) SetCapacity(value int) {
+	C.rocksdb_lru_cache_options_set_capacity(opts.c, C.size_t(value))
+}
+
+// SetNumShardBits sets the number of bits of a key used to select its
+// shard, the cache is sharded into 2^numShardBits shards.
+// Default: -1, picks a value based on the capacity
+func (opts *LRUCacheOptions) SetNumShardBits(value int) {
+	C.rocksdb_lru_cache_options_set_num_shard_bits(opts.c, C.int(value))
+}
+
+// Destroy deallocates the LRUCacheOptions object.
+func (opts *LRUCacheOptions) Destroy() {
+	C.rocksdb_lru_cache_options_destroy(opts.c)
+	opts.c = nil
+}
+
+// HyperClockCacheOptions represent the options of a HyperClockCache.
+type HyperClockCacheOptions struct {
+	c *C.rocksdb_hyper_clock_cache_options_t
+}
+
+// NewDefaultHyperClockCacheOptions creates a HyperClockCacheOptions object
+// with the given capacity and estimated entry charge, see
+// NewHyperClockCache.
+func NewDefaultHyperClockCacheOptions(capacity, estimatedEntryCharge int) *HyperClockCacheOptions {
+	return NewNativeHyperClockCacheOptions(C.rocksdb_hyper_clock_cache_options_create(C.size_t(capacity), C.size_t(estimatedEntryCharge)))
+}
+
+// NewNativeHyperClockCacheOptions creates a HyperClockCacheOptions object.
+func NewNativeHyperClockCacheOptions(c *C.rocksdb_hyper_clock_cache_options_t) *HyperClockCacheOptions {
+	return &HyperClockCacheOptions{c}
+}
+
+// SetCapacity sets the capacity of the cache.
+func (opts *HyperClockCacheOptions) SetCapacity(value int) {
+	C.rocksdb_hyper_clock_cache_options_set_capacity(opts.c, C.size_t(value))
+}
+
+// SetEstimatedEntryCharge sets the expected average size of an entry.
+func (opts *HyperClockCacheOptions) SetEstimatedEntryCharge(value int) {
+	C.rocksdb_hyper_clock_cache_options_set_estimated_entry_charge(opts.c, C.size_t(value))
+}
+
+// SetNumShardBits sets the number of bits of a key used to select its
+// shard, the cache is sharded into 2^numShardBits shards.
+// Default: -1, picks a value based on the capacity
+func (opts *HyperClockCacheOptions) SetNumShardBits(value int) {
+	C.rocksdb_hyper_clock_cache_options_set_num_shard_bits(opts.c, C.int(value))
+}
+
+// Destroy deallocates the HyperClockCacheOptions object.
+func (opts *HyperClockCacheOptions) Destroy() {
+	C.rocksdb_hyper_clock_cache_options_destroy(opts.c)
+	opts.c = nil
+}
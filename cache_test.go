@@ -0,0 +1,52 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestCacheUsage(t *testing.T) {
+	opts := NewDefaultLRUCacheOptions()
+	opts.SetCapacity(8 << 20)
+	opts.SetNumShardBits(2)
+	lru := NewLRUCacheWithOptions(opts)
+	opts.Destroy()
+
+	clock := NewHyperClockCache(8<<20, 4<<10)
+
+	for name, cache := range map[string]*Cache{"LRU": lru, "HyperClock": clock} {
+		ensure.DeepEqual(t, cache.GetCapacity(), 8<<20, name)
+		ensure.DeepEqual(t, cache.GetUsage(), 0, name)
+
+		db := newTestDB(t, "TestCacheUsage"+name, func(opts *Options) {
+			bbto := NewDefaultBlockBasedTableOptions()
+			bbto.SetBlockCache(cache)
+			opts.SetBlockBasedTableFactory(bbto)
+		})
+
+		wo := NewDefaultWriteOptions()
+		for i := 0; i < 1000; i++ {
+			ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%04d", i)), []byte("val")))
+		}
+		ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+		// reading the table fills the cache
+		ro := NewDefaultReadOptions()
+		v, err := db.Get(ro, []byte("key0500"))
+		ensure.Nil(t, err)
+		v.Free()
+		ensure.True(t, cache.GetUsage() > 0, name)
+		ensure.True(t, cache.GetPinnedUsage() <= cache.GetUsage(), name)
+
+		// the capacity can be changed while the cache is in use
+		cache.SetCapacity(16 << 20)
+		ensure.DeepEqual(t, cache.GetCapacity(), 16<<20, name)
+
+		ro.Destroy()
+		wo.Destroy()
+		db.Close()
+		cache.Destroy()
+	}
+}
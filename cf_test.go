@@ -52,6 +52,33 @@ func TestColumnFamilyCreateDrop(t *testing.T) {
 	ensure.SameElements(t, actualNames, []string{"default"})
 }
 
+func TestWriteOptionsIgnoreMissingColumnFamilies(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestWriteOptionsIgnoreMissingColumnFamilies")
+	ensure.Nil(t, err)
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+	defer db.Close()
+	cf, err := db.CreateColumnFamily(opts, "guide")
+	ensure.Nil(t, err)
+	defer cf.Destroy()
+
+	wb := NewWriteBatch()
+	defer wb.Destroy()
+	wb.PutCF(cf, []byte("foo"), []byte("bar"))
+	ensure.Nil(t, db.DropColumnFamily(cf))
+
+	// the batch refers to the dropped column family
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.NotNil(t, db.Write(wo, wb))
+	wo.SetIgnoreMissingColumnFamilies(true)
+	ensure.True(t, wo.GetIgnoreMissingColumnFamilies())
+	ensure.Nil(t, db.Write(wo, wb))
+}
+
 func TestColumnFamilyBatchPutGet(t *testing.T) {
 	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamilyPutGet")
 	ensure.Nil(t, err)
@@ -152,3 +179,36 @@ func TestColumnFamilyPutGetDelete(t *testing.T) {
 	ensure.Nil(t, err)
 	ensure.DeepEqual(t, actualVal.Size(), 0)
 }
+
+func TestColumnFamilyOptionsLifetime(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestColumnFamilyOptionsLifetime")
+	ensure.Nil(t, err)
+
+	givenNames := []string{"default", "guide"}
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	opts.SetComparator(&bytesReverseComparator{})
+	db, cfh, err := OpenDbColumnFamilies(opts, dir, givenNames, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	defer db.Close()
+	defer cfh[0].Destroy()
+	defer cfh[1].Destroy()
+
+	// the DB keeps its own references to the options and the comparator
+	opts.Destroy()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	for _, k := range []string{"key1", "key2", "key3"} {
+		ensure.Nil(t, db.PutCF(wo, cfh[1], []byte(k), []byte("val")))
+	}
+
+	iter := db.NewIteratorCF(ro, cfh[1])
+	defer iter.Close()
+	iter.SeekToFirst()
+	ensure.True(t, iter.Valid())
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("key3"))
+}
@@ -1,6 +1,7 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
 
 // A CompactionFilter can be used to filter keys during compaction time.
@@ -25,9 +26,22 @@ type CompactionFilter interface {
 	Name() string
 }
 
-// NewNativeCompactionFilter creates a CompactionFilter object.
-func NewNativeCompactionFilter(c *C.rocksdb_comparator_t) Comparator {
-	return nativeComparator{c}
+// NewNativeCompactionFilter creates a CompactionFilter object. The Options
+// it is passed to takes ownership of c, so it must only be passed to one
+// Options object. Likewise RocksDB destroys a filter returned by a
+// CompactionFilterFactory after the compaction, so the factory must create
+// a new one each time. Its Filter method can't call c and keeps all entries.
+func NewNativeCompactionFilter(c *C.rocksdb_compactionfilter_t) CompactionFilter {
+	return nativeCompactionFilter{c}
+}
+
+// NewRemoveEmptyValueCompactionFilter creates a compaction filter which
+// removes entries with an empty value, like RocksDB's
+// RemoveEmptyValueCompactionFilter. It is implemented in C, so it does not
+// cost a cgo callback per entry. See NewNativeCompactionFilter for its
+// ownership.
+func NewRemoveEmptyValueCompactionFilter() CompactionFilter {
+	return NewNativeCompactionFilter(C.gorocksdb_compactionfilter_remove_empty_value_create())
 }
 
 type nativeCompactionFilter struct {
@@ -39,12 +53,13 @@ func (c nativeCompactionFilter) Filter(level int, key, val []byte) (remove bool,
 }
 func (c nativeCompactionFilter) Name() string { return "" }
 
-// Hold references to compaction filters.
-var compactionFilters []CompactionFilter
+func registerCompactionFilter(filter CompactionFilter) uintptr {
+	return handles.register(filter)
+}
 
-func registerCompactionFilter(filter CompactionFilter) int {
-	compactionFilters = append(compactionFilters, filter)
-	return len(compactionFilters) - 1
+// createCompactionFilter creates a C compaction filter which calls into filter.
+func createCompactionFilter(filter CompactionFilter) *C.rocksdb_compactionfilter_t {
+	return C.gorocksdb_compactionfilter_create(C.uintptr_t(registerCompactionFilter(filter)))
 }
 
 //export gorocksdb_compactionfilter_filter
@@ -52,7 +67,7 @@ func gorocksdb_compactionfilter_filter(idx int, cLevel C.int, cKey *C.char, cKey
 	key := charToByte(cKey, cKeyLen)
 	val := charToByte(cVal, cValLen)
 
-	remove, newVal := compactionFilters[idx].Filter(int(cLevel), key, val)
+	remove, newVal := handles.get(uintptr(idx)).(CompactionFilter).Filter(int(cLevel), key, val)
 	if remove {
 		return C.int(1)
 	} else if newVal != nil {
@@ -65,5 +80,5 @@ func gorocksdb_compactionfilter_filter(idx int, cLevel C.int, cKey *C.char, cKey
 
 //export gorocksdb_compactionfilter_name
 func gorocksdb_compactionfilter_name(idx int) *C.char {
-	return stringToChar(compactionFilters[idx].Name())
+	return stringToChar(handles.get(uintptr(idx)).(CompactionFilter).Name())
 }
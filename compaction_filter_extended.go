@@ -0,0 +1,68 @@
+package gorocksdb
+
+// CompactionValueType is the type of an entry seen by an
+// ExtendedCompactionFilter.
+//
+// The C API of RocksDB only passes plain values to compaction filters, so
+// that is the only type. Merge operands and blob indexes are always kept.
+type CompactionValueType int
+
+// Compaction value types.
+const (
+	CompactionValueTypeValue = CompactionValueType(iota)
+)
+
+// CompactionDecision tells what to do with an entry during compaction.
+//
+// RocksDB's RemoveAndSkipUntil and ChangeValueToWideColumns decisions are
+// not available, the C API has no way to return them.
+type CompactionDecision int
+
+// Compaction decisions.
+const (
+	// CompactionDecisionKeep keeps the entry.
+	CompactionDecisionKeep = CompactionDecision(iota)
+	// CompactionDecisionRemove removes the entry.
+	CompactionDecisionRemove
+	// CompactionDecisionChangeValue replaces the value of the entry.
+	CompactionDecisionChangeValue
+)
+
+// An ExtendedCompactionFilter can be used to filter keys during compaction
+// time. Compared to a CompactionFilter it receives the type of an entry and
+// returns an explicit decision.
+type ExtendedCompactionFilter interface {
+	// FilterV2 decides what to do with an entry. newVal is used for
+	// CompactionDecisionChangeValue.
+	//
+	// Like CompactionFilter.Filter it may be called from different threads
+	// concurrently if it is not created by a CompactionFilterFactory.
+	FilterV2(level int, key []byte, valueType CompactionValueType, val []byte) (decision CompactionDecision, newVal []byte)
+
+	// The name of the compaction filter, for logging
+	Name() string
+}
+
+// NewExtendedCompactionFilter creates a CompactionFilter from an
+// ExtendedCompactionFilter, which can be passed to SetCompactionFilter or
+// returned by a CompactionFilterFactory.
+func NewExtendedCompactionFilter(filter ExtendedCompactionFilter) CompactionFilter {
+	return extendedCompactionFilter{filter}
+}
+
+type extendedCompactionFilter struct {
+	filter ExtendedCompactionFilter
+}
+
+func (f extendedCompactionFilter) Name() string { return f.filter.Name() }
+
+func (f extendedCompactionFilter) Filter(level int, key, val []byte) (bool, []byte) {
+	decision, newVal := f.filter.FilterV2(level, key, CompactionValueTypeValue, val)
+	switch decision {
+	case CompactionDecisionRemove:
+		return true, nil
+	case CompactionDecisionChangeValue:
+		return false, newVal
+	}
+	return false, nil
+}
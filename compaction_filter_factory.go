@@ -0,0 +1,71 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+// #include "gorocksdb.h"
+import "C"
+
+// CompactionFilterContext describes the compaction a CompactionFilter
+// is created for.
+//
+// The C API doesn't tell whether a compaction is bottommost, so there
+// is no field for it.
+type CompactionFilterContext struct {
+	// IsFullCompaction is true if the compaction includes all files.
+	IsFullCompaction bool
+	// IsManualCompaction is true if the compaction was requested by the
+	// application, e.g. with CompactRange.
+	IsManualCompaction bool
+}
+
+// A CompactionFilterFactory creates a new CompactionFilter for each
+// compaction. A created filter is only used by a single compaction thread,
+// so it can keep per-compaction state without locking.
+type CompactionFilterFactory interface {
+	// CreateCompactionFilter returns the filter for the compaction described
+	// by ctx, or nil if the compaction should not be filtered.
+	CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter
+
+	// The name of the compaction filter factory, for logging
+	Name() string
+}
+
+// NewNativeCompactionFilterFactory creates a CompactionFilterFactory object.
+func NewNativeCompactionFilterFactory(c *C.rocksdb_compactionfilterfactory_t) CompactionFilterFactory {
+	return nativeCompactionFilterFactory{c}
+}
+
+type nativeCompactionFilterFactory struct {
+	c *C.rocksdb_compactionfilterfactory_t
+}
+
+func (f nativeCompactionFilterFactory) CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter {
+	return nil
+}
+func (f nativeCompactionFilterFactory) Name() string { return "" }
+
+func registerCompactionFilterFactory(factory CompactionFilterFactory) uintptr {
+	return handles.register(factory)
+}
+
+//export gorocksdb_compactionfilterfactory_create_filter
+func gorocksdb_compactionfilterfactory_create_filter(idx int, cCtx *C.rocksdb_compactionfiltercontext_t) *C.rocksdb_compactionfilter_t {
+	ctx := CompactionFilterContext{
+		IsFullCompaction:   charToBool(C.rocksdb_compactionfiltercontext_is_full_compaction(cCtx)),
+		IsManualCompaction: charToBool(C.rocksdb_compactionfiltercontext_is_manual_compaction(cCtx)),
+	}
+	filter := handles.get(uintptr(idx)).(CompactionFilterFactory).CreateCompactionFilter(ctx)
+	if filter == nil {
+		return nil
+	}
+	// RocksDB takes ownership of the returned filter and destroys it
+	// after the compaction, which releases its handle.
+	if nc, ok := filter.(nativeCompactionFilter); ok {
+		return nc.c
+	}
+	return createCompactionFilter(filter)
+}
+
+//export gorocksdb_compactionfilterfactory_name
+func gorocksdb_compactionfilterfactory_name(idx int) *C.char {
+	return stringToChar(handles.get(uintptr(idx)).(CompactionFilterFactory).Name())
+}
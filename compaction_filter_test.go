@@ -51,6 +51,125 @@ func TestCompactionFilter(t *testing.T) {
 	ensure.True(t, v2.Data() == nil)
 }
 
+func TestNativeCompactionFilter(t *testing.T) {
+	db := newTestDB(t, "TestNativeCompactionFilter", func(opts *Options) {
+		filter := NewRemoveEmptyValueCompactionFilter()
+		_, ok := filter.(nativeCompactionFilter)
+		ensure.True(t, ok)
+		opts.SetCompactionFilter(filter)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("empty"), nil))
+	ensure.Nil(t, db.Put(wo, []byte("keep"), []byte("val")))
+
+	// trigger a compaction
+	db.CompactRange(Range{nil, nil})
+
+	// only the entry with a value is left
+	ro := NewDefaultReadOptions()
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	var keys []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, keys, []string{"keep"})
+}
+
+func TestCompactionFilterFactory(t *testing.T) {
+	var (
+		deleteKey = []byte("delete")
+		keepKey   = []byte("keep")
+		contexts  = make(chan CompactionFilterContext, 16)
+	)
+	db := newTestDB(t, "TestCompactionFilterFactory", func(opts *Options) {
+		opts.SetCompactionFilterFactory(&mockCompactionFilterFactory{
+			create: func(ctx CompactionFilterContext) CompactionFilter {
+				contexts <- ctx
+				return &mockCompactionFilter{
+					filter: func(level int, key, val []byte) (remove bool, newVal []byte) {
+						return bytes.Equal(key, deleteKey), nil
+					},
+				}
+			},
+		})
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, deleteKey, []byte("val")))
+	ensure.Nil(t, db.Put(wo, keepKey, []byte("val")))
+
+	// trigger a manual compaction
+	db.CompactRange(Range{nil, nil})
+
+	ctx := <-contexts
+	ensure.True(t, ctx.IsManualCompaction)
+
+	ro := NewDefaultReadOptions()
+	v1, err := db.Get(ro, deleteKey)
+	ensure.Nil(t, err)
+	ensure.True(t, v1.Data() == nil)
+	v2, err := db.Get(ro, keepKey)
+	defer v2.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v2.Data(), []byte("val"))
+}
+
+func TestExtendedCompactionFilter(t *testing.T) {
+	db := newTestDB(t, "TestExtendedCompactionFilter", func(opts *Options) {
+		opts.SetCompactionFilter(NewExtendedCompactionFilter(&mockExtendedCompactionFilter{
+			filter: func(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte) {
+				ensure.DeepEqual(t, valueType, CompactionValueTypeValue)
+				switch string(key) {
+				case "expired":
+					return CompactionDecisionRemove, nil
+				case "change":
+					return CompactionDecisionChangeValue, []byte("new")
+				}
+				return CompactionDecisionKeep, nil
+			},
+		}))
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	for _, k := range []string{"expired", "change", "keep"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("old")))
+	}
+
+	// trigger a compaction
+	db.CompactRange(Range{nil, nil})
+
+	ro := NewDefaultReadOptions()
+	for k, want := range map[string][]byte{"expired": nil, "change": []byte("new"), "keep": []byte("old")} {
+		v, err := db.GetBytes(ro, []byte(k))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v, want, k)
+	}
+}
+
+type mockExtendedCompactionFilter struct {
+	filter func(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte)
+}
+
+func (m *mockExtendedCompactionFilter) Name() string { return "gorocksdb.test" }
+func (m *mockExtendedCompactionFilter) FilterV2(level int, key []byte, valueType CompactionValueType, val []byte) (CompactionDecision, []byte) {
+	return m.filter(level, key, valueType, val)
+}
+
+type mockCompactionFilterFactory struct {
+	create func(ctx CompactionFilterContext) CompactionFilter
+}
+
+func (m *mockCompactionFilterFactory) Name() string { return "gorocksdb.test" }
+func (m *mockCompactionFilterFactory) CreateCompactionFilter(ctx CompactionFilterContext) CompactionFilter {
+	return m.create(ctx)
+}
+
 type mockCompactionFilter struct {
 	filter func(level int, key, val []byte) (remove bool, newVal []byte)
 }
@@ -1,7 +1,12 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
+import (
+	"bytes"
+	"encoding/binary"
+)
 
 // A Comparator object provides a total order across slices that are
 // used as keys in an sstable or a database.
@@ -16,34 +21,184 @@ type Comparator interface {
 	Name() string
 }
 
-// NewNativeComparator creates a Comparator object.
+// A TimestampComparator is a Comparator for keys with a user-defined
+// timestamp. Every key written to the database ends with a timestamp of
+// TimestampSize bytes, which Compare must take into account: keys are
+// ordered ascending and equal keys by descending timestamp, so that newer
+// entries come first.
+type TimestampComparator interface {
+	Comparator
+
+	// The size of a timestamp in bytes.
+	TimestampSize() int
+
+	// Three-way comparison of two timestamps.
+	CompareTimestamp(a, b []byte) int
+
+	// Three-way comparison of two keys, ignoring their timestamps.
+	// aHasTs and bHasTs tell whether the keys end with a timestamp.
+	CompareWithoutTimestamp(a []byte, aHasTs bool, b []byte, bHasTs bool) int
+}
+
+// NewNativeComparator creates a Comparator object. The Options it is passed
+// to takes ownership of c, so it must only be passed to one Options object.
+// Its Compare method can't call c and always returns 0.
 func NewNativeComparator(c *C.rocksdb_comparator_t) Comparator {
-	return nativeComparator{c}
+	return nativeComparator{c: c}
+}
+
+// NewReverseBytewiseComparator creates a comparator which orders keys in
+// reverse lexicographic byte-wise order, like RocksDB's
+// ReverseBytewiseComparator. It is implemented in C, so unlike a Go
+// comparator it does not cost a cgo callback per comparison.
+func NewReverseBytewiseComparator() Comparator {
+	return nativeComparator{
+		create: func() *C.rocksdb_comparator_t {
+			return C.gorocksdb_reverse_bytewise_comparator_create()
+		},
+		name: "rocksdb.ReverseBytewiseComparator",
+		compare: func(a, b []byte) int {
+			return -bytes.Compare(a, b)
+		},
+	}
+}
+
+// NewUint64Comparator creates a comparator for 8 byte keys which are
+// big-endian encoded unsigned integers, ordered by their value. Keys of a
+// different size are ordered byte-wise. It is implemented in C.
+func NewUint64Comparator() Comparator {
+	return nativeComparator{
+		create: func() *C.rocksdb_comparator_t {
+			return C.gorocksdb_uint64_comparator_create()
+		},
+		name:    "gorocksdb.Uint64Comparator",
+		compare: compareUint64,
+	}
+}
+
+// NewBytewiseComparatorWithU64Ts creates a TimestampComparator which orders
+// keys byte-wise and equal keys by descending timestamp. Timestamps are
+// 8 byte little-endian encoded unsigned integers. It is compatible with
+// RocksDB's BytewiseComparatorWithU64Ts and implemented in C.
+func NewBytewiseComparatorWithU64Ts() TimestampComparator {
+	return nativeTimestampComparator{nativeComparator{
+		create: func() *C.rocksdb_comparator_t {
+			return C.gorocksdb_bytewise_comparator_with_u64ts_create()
+		},
+		name:    "leveldb.BytewiseComparator.u64ts",
+		compare: compareU64Ts,
+	}}
 }
 
 type nativeComparator struct {
 	c *C.rocksdb_comparator_t
+	// create returns a new C comparator. It is used instead of c by the
+	// comparators of this package, so that every Options gets its own.
+	create func() *C.rocksdb_comparator_t
+
+	// Go implementation of the comparator, if known.
+	name    string
+	compare func(a, b []byte) int
+}
+
+// cComparator returns the C comparator for a new Options object.
+func (c nativeComparator) cComparator() *C.rocksdb_comparator_t {
+	if c.create != nil {
+		return c.create()
+	}
+	return c.c
+}
+
+func (c nativeComparator) Name() string { return c.name }
+func (c nativeComparator) Compare(a, b []byte) int {
+	if c.compare == nil {
+		return 0
+	}
+	return c.compare(a, b)
+}
+
+func compareUint64(a, b []byte) int {
+	if len(a) != 8 || len(b) != 8 {
+		return bytes.Compare(a, b)
+	}
+	x, y := binary.BigEndian.Uint64(a), binary.BigEndian.Uint64(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
 }
 
-func (c nativeComparator) Compare(a, b []byte) int { return 0 }
-func (c nativeComparator) Name() string            { return "" }
+const u64TsSize = 8
+
+type nativeTimestampComparator struct {
+	nativeComparator
+}
 
-// Hold references to comperators.
-var comperators []Comparator
+func (c nativeTimestampComparator) TimestampSize() int { return u64TsSize }
+
+func (c nativeTimestampComparator) CompareTimestamp(a, b []byte) int {
+	return compareU64Timestamp(a, b)
+}
+
+func (c nativeTimestampComparator) CompareWithoutTimestamp(a []byte, aHasTs bool, b []byte, bHasTs bool) int {
+	if aHasTs {
+		a = a[:len(a)-u64TsSize]
+	}
+	if bHasTs {
+		b = b[:len(b)-u64TsSize]
+	}
+	return bytes.Compare(a, b)
+}
+
+// compareU64Ts compares keys ending with little-endian uint64 timestamps
+// like BytewiseComparatorWithU64Ts.
+func compareU64Ts(a, b []byte) int {
+	if r := bytes.Compare(a[:len(a)-u64TsSize], b[:len(b)-u64TsSize]); r != 0 {
+		return r
+	}
+	return -compareU64Timestamp(a[len(a)-u64TsSize:], b[len(b)-u64TsSize:])
+}
 
-func registerComperator(cmp Comparator) int {
-	comperators = append(comperators, cmp)
-	return len(comperators) - 1
+func compareU64Timestamp(a, b []byte) int {
+	x, y := binary.LittleEndian.Uint64(a), binary.LittleEndian.Uint64(b)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
+}
+
+func registerComperator(cmp Comparator) uintptr {
+	return handles.register(cmp)
 }
 
 //export gorocksdb_comparator_compare
 func gorocksdb_comparator_compare(idx int, cKeyA *C.char, cKeyALen C.size_t, cKeyB *C.char, cKeyBLen C.size_t) C.int {
 	keyA := charToByte(cKeyA, cKeyALen)
 	keyB := charToByte(cKeyB, cKeyBLen)
-	return C.int(comperators[idx].Compare(keyA, keyB))
+	return C.int(handles.get(uintptr(idx)).(Comparator).Compare(keyA, keyB))
 }
 
 //export gorocksdb_comparator_name
 func gorocksdb_comparator_name(idx int) *C.char {
-	return stringToChar(comperators[idx].Name())
+	return stringToChar(handles.get(uintptr(idx)).(Comparator).Name())
+}
+
+//export gorocksdb_comparator_compare_ts
+func gorocksdb_comparator_compare_ts(idx int, cTsA *C.char, cTsALen C.size_t, cTsB *C.char, cTsBLen C.size_t) C.int {
+	tsA := charToByte(cTsA, cTsALen)
+	tsB := charToByte(cTsB, cTsBLen)
+	return C.int(handles.get(uintptr(idx)).(TimestampComparator).CompareTimestamp(tsA, tsB))
+}
+
+//export gorocksdb_comparator_compare_without_ts
+func gorocksdb_comparator_compare_without_ts(idx int, cKeyA *C.char, cKeyALen C.size_t, cAHasTs C.uchar, cKeyB *C.char, cKeyBLen C.size_t, cBHasTs C.uchar) C.int {
+	keyA := charToByte(cKeyA, cKeyALen)
+	keyB := charToByte(cKeyB, cKeyBLen)
+	return C.int(handles.get(uintptr(idx)).(TimestampComparator).CompareWithoutTimestamp(keyA, charToBool(cAHasTs), keyB, charToBool(cBHasTs)))
 }
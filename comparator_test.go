@@ -2,6 +2,8 @@ package gorocksdb
 
 import (
 	"bytes"
+	"encoding/binary"
+	"io/ioutil"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -39,9 +41,243 @@ func TestComparator(t *testing.T) {
 	ensure.DeepEqual(t, actualKeys, givenKeys)
 }
 
+func TestNativeComparators(t *testing.T) {
+	u64 := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, v)
+		return b
+	}
+
+	for _, c := range []struct {
+		name string
+		cmp  func() Comparator
+		keys [][]byte
+	}{
+		{"Reverse", NewReverseBytewiseComparator, [][]byte{[]byte("key3"), []byte("key2"), []byte("key1")}},
+		{"Uint64", NewUint64Comparator, [][]byte{u64(1), u64(2), u64(256), u64(1 << 40)}},
+	} {
+		// the Go implementation must agree with the C one
+		for i := 1; i < len(c.keys); i++ {
+			ensure.True(t, c.cmp().Compare(c.keys[i-1], c.keys[i]) < 0, c.name)
+		}
+
+		db := newTestDB(t, "TestNativeComparators"+c.name, func(opts *Options) {
+			opts.SetComparator(c.cmp())
+		})
+		wo := NewDefaultWriteOptions()
+		for i := len(c.keys) - 1; i >= 0; i-- {
+			ensure.Nil(t, db.Put(wo, c.keys[i], []byte("val")))
+		}
+		ro := NewDefaultReadOptions()
+		iter := db.NewIterator(ro)
+		var actualKeys [][]byte
+		for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+			actualKeys = append(actualKeys, append([]byte{}, iter.Key().Data()...))
+		}
+		ensure.Nil(t, iter.Err())
+		ensure.DeepEqual(t, actualKeys, c.keys)
+		iter.Close()
+		wo.Destroy()
+		ro.Destroy()
+		db.Close()
+	}
+}
+
+func TestNativeTimestampComparator(t *testing.T) {
+	cmp := NewBytewiseComparatorWithU64Ts()
+	ensure.DeepEqual(t, cmp.TimestampSize(), 8)
+	ensure.DeepEqual(t, cmp.Name(), "leveldb.BytewiseComparator.u64ts")
+
+	db := newTestDB(t, "TestNativeTimestampComparator", func(opts *Options) {
+		opts.SetComparator(cmp)
+	})
+	defer db.Close()
+
+	ts := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.PutWithTS(wo, []byte("foo"), ts(1), []byte("v1")))
+	ensure.Nil(t, db.PutWithTS(wo, []byte("foo"), ts(3), []byte("v3")))
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ro.SetTimestamp(ts(2))
+	v, vTs, err := db.GetWithTS(ro, []byte("foo"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	defer vTs.Free()
+	ensure.DeepEqual(t, v.Data(), []byte("v1"))
+	ensure.DeepEqual(t, vTs.Data(), ts(1))
+
+	// newer entries of a key come first
+	ensure.True(t, cmp.Compare(append([]byte("foo"), ts(3)...), append([]byte("foo"), ts(1)...)) < 0)
+	ensure.True(t, cmp.Compare(append([]byte("bar"), ts(1)...), append([]byte("foo"), ts(3)...)) < 0)
+}
+
+func TestNativeComparatorShared(t *testing.T) {
+	// every Options creates and destroys its own C comparator
+	cmp := NewReverseBytewiseComparator()
+	opts1 := NewDefaultOptions()
+	opts1.SetComparator(cmp)
+	opts2 := NewDefaultOptions()
+	opts2.SetComparator(cmp)
+	opts1.Destroy()
+
+	db := newTestDB(t, "TestNativeComparatorShared", func(opts *Options) {
+		opts.SetComparator(opts2.GetComparator())
+	})
+	defer db.Close()
+	opts2.Destroy()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("key1"), []byte("val")))
+	ensure.Nil(t, db.Put(wo, []byte("key2"), []byte("val")))
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	iter.SeekToFirst()
+	ensure.True(t, iter.Valid())
+	ensure.DeepEqual(t, iter.Key().Data(), []byte("key2"))
+}
+
+func BenchmarkComparatorReverseGo(b *testing.B) {
+	benchmarkComparator(b, &bytesReverseComparator{})
+}
+
+func BenchmarkComparatorReverseNative(b *testing.B) {
+	benchmarkComparator(b, NewReverseBytewiseComparator())
+}
+
+func BenchmarkComparatorUint64Go(b *testing.B) {
+	benchmarkComparator(b, &uint64Comparator{})
+}
+
+func BenchmarkComparatorUint64Native(b *testing.B) {
+	benchmarkComparator(b, NewUint64Comparator())
+}
+
+// benchmarkComparator measures writes to the memtable, each of which
+// compares the key with the keys already written.
+func benchmarkComparator(b *testing.B, cmp Comparator) {
+	dir, err := ioutil.TempDir("", "gorocksdb-BenchmarkComparator")
+	ensure.Nil(b, err)
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(true)
+	opts.SetComparator(cmp)
+	db, err := OpenDb(opts, dir)
+	opts.Destroy()
+	ensure.Nil(b, err)
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	wo.DisableWAL(true)
+	defer wo.Destroy()
+	key := make([]byte, 8)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		binary.BigEndian.PutUint64(key, uint64(i)*0x9E3779B97F4A7C15)
+		if err := db.Put(wo, key, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+type uint64Comparator struct{}
+
+func (cmp *uint64Comparator) Name() string { return "gorocksdb.Uint64Comparator" }
+func (cmp *uint64Comparator) Compare(a, b []byte) int {
+	return compareUint64(a, b)
+}
+
 type bytesReverseComparator struct{}
 
 func (cmp *bytesReverseComparator) Name() string { return "gorocksdb.bytes-reverse" }
 func (cmp *bytesReverseComparator) Compare(a, b []byte) int {
 	return bytes.Compare(a, b) * -1
 }
+
+func TestTimestampComparator(t *testing.T) {
+	db := newTestDB(t, "TestTimestampComparator", func(opts *Options) {
+		opts.SetComparator(&uint64TimestampComparator{})
+	})
+	defer db.Close()
+
+	ts := func(v uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, v)
+		return b
+	}
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.PutWithTS(wo, []byte("foo"), ts(1), []byte("v1")))
+	ensure.Nil(t, db.PutWithTS(wo, []byte("foo"), ts(3), []byte("v3")))
+	ensure.Nil(t, db.DeleteWithTS(wo, []byte("foo"), ts(5)))
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+
+	// read at different points in time
+	for readTs, want := range map[uint64]string{2: "v1", 4: "v3", 6: ""} {
+		ro.SetTimestamp(ts(readTs))
+		ensure.DeepEqual(t, ro.GetTimestamp(), ts(readTs))
+		v, vTs, err := db.GetWithTS(ro, []byte("foo"))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, string(v.Data()), want)
+		if want != "" {
+			ensure.DeepEqual(t, vTs.Size(), 8)
+		}
+		v.Free()
+		vTs.Free()
+	}
+
+	// iterate over all versions
+	ro.SetTimestamp(ts(4))
+	ro.SetIterStartTimestamp(ts(0))
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	var versions []uint64
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		versions = append(versions, binary.LittleEndian.Uint64(iter.Timestamp().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, versions, []uint64{3, 1})
+}
+
+// uint64TimestampComparator orders keys byte-wise and equal keys by
+// descending little-endian uint64 timestamps.
+type uint64TimestampComparator struct{}
+
+func (cmp *uint64TimestampComparator) Name() string       { return "gorocksdb.uint64-ts" }
+func (cmp *uint64TimestampComparator) TimestampSize() int { return 8 }
+func (cmp *uint64TimestampComparator) Compare(a, b []byte) int {
+	if r := cmp.CompareWithoutTimestamp(a, true, b, true); r != 0 {
+		return r
+	}
+	return -cmp.CompareTimestamp(a[len(a)-8:], b[len(b)-8:])
+}
+func (cmp *uint64TimestampComparator) CompareTimestamp(a, b []byte) int {
+	tsA, tsB := binary.LittleEndian.Uint64(a), binary.LittleEndian.Uint64(b)
+	switch {
+	case tsA < tsB:
+		return -1
+	case tsA > tsB:
+		return 1
+	}
+	return 0
+}
+func (cmp *uint64TimestampComparator) CompareWithoutTimestamp(a []byte, aHasTs bool, b []byte, bHasTs bool) int {
+	if aHasTs {
+		a = a[:len(a)-8]
+	}
+	if bHasTs {
+		b = b[:len(b)-8]
+	}
+	return bytes.Compare(a, b)
+}
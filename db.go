@@ -19,7 +19,11 @@ type Range struct {
 type DB struct {
 	c    *C.rocksdb_t
 	name string
-	opts *Options
+
+	// The DB keeps its own copies of the options it was opened with, so
+	// that callbacks stay alive when the caller destroys them.
+	opts   *Options
+	cfOpts []*Options
 }
 
 // OpenDb opens a database with the specified options.
@@ -37,7 +41,7 @@ func OpenDb(opts *Options, name string) (*DB, error) {
 	return &DB{
 		name: name,
 		c:    db,
-		opts: opts,
+		opts: opts.Clone(),
 	}, nil
 }
 
@@ -56,7 +60,7 @@ func OpenDbForReadOnly(opts *Options, name string, errorIfLogFileExist bool) (*D
 	return &DB{
 		name: name,
 		c:    db,
-		opts: opts,
+		opts: opts.Clone(),
 	}, nil
 }
 
@@ -113,9 +117,10 @@ func OpenDbColumnFamilies(
 	}
 
 	return &DB{
-		name: name,
-		c:    db,
-		opts: opts,
+		name:   name,
+		c:      db,
+		opts:   opts.Clone(),
+		cfOpts: cloneOptions(cfOpts),
 	}, cfHandles, nil
 }
 
@@ -175,12 +180,21 @@ func OpenDbForReadOnlyColumnFamilies(
 	}
 
 	return &DB{
-		name: name,
-		c:    db,
-		opts: opts,
+		name:   name,
+		c:      db,
+		opts:   opts.Clone(),
+		cfOpts: cloneOptions(cfOpts),
 	}, cfHandles, nil
 }
 
+func cloneOptions(opts []*Options) []*Options {
+	cloned := make([]*Options, len(opts))
+	for i, o := range opts {
+		cloned[i] = o.Clone()
+	}
+	return cloned
+}
+
 // ListColumnFamilies lists the names of the column families in the DB.
 func ListColumnFamilies(opts *Options, name string) ([]string, error) {
 	var (
@@ -229,6 +243,21 @@ func (db *DB) Get(opts *ReadOptions, key []byte) (*Slice, error) {
 	return NewSlice(cValue, cValLen), nil
 }
 
+// KeyMayExist returns false if the key definitely does not exist in the
+// database. It only checks the memtables, the filters and the data in the
+// block cache, so it is cheaper than Get and true may be a false positive.
+func (db *DB) KeyMayExist(opts *ReadOptions, key []byte) bool {
+	cKey := byteToChar(key)
+	return charToBool(C.rocksdb_key_may_exist(db.c, opts.c, cKey, C.size_t(len(key)), nil, nil, nil, 0, nil))
+}
+
+// KeyMayExistCF returns false if the key definitely does not exist in the
+// database and column family.
+func (db *DB) KeyMayExistCF(opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) bool {
+	cKey := byteToChar(key)
+	return charToBool(C.rocksdb_key_may_exist_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), nil, nil, nil, 0, nil))
+}
+
 // GetBytes is like Get but returns a copy of the data.
 func (db *DB) GetBytes(opts *ReadOptions, key []byte) ([]byte, error) {
 	var (
@@ -273,7 +302,7 @@ func (db *DB) Put(opts *WriteOptions, key, value []byte) error {
 	C.rocksdb_put(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -288,7 +317,7 @@ func (db *DB) PutCF(opts *WriteOptions, cf *ColumnFamilyHandle, key, value []byt
 	C.rocksdb_put_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -302,7 +331,7 @@ func (db *DB) Delete(opts *WriteOptions, key []byte) error {
 	C.rocksdb_delete(db.c, opts.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -316,7 +345,7 @@ func (db *DB) DeleteCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte) e
 	C.rocksdb_delete_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -331,7 +360,7 @@ func (db *DB) Merge(opts *WriteOptions, key []byte, value []byte) error {
 	C.rocksdb_merge(db.c, opts.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -347,7 +376,109 @@ func (db *DB) MergeCF(opts *WriteOptions, cf *ColumnFamilyHandle, key []byte, va
 	C.rocksdb_merge_cf(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cValue, C.size_t(len(value)), &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// GetWithTS returns the data and the timestamp associated with the key from
+// the database. The timestamp to read at is set with ReadOptions.SetTimestamp.
+func (db *DB) GetWithTS(opts *ReadOptions, key []byte) (value *Slice, ts *Slice, err error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cTs     *C.char
+		cTsLen  C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_get_with_ts(db.c, opts.c, cKey, C.size_t(len(key)), &cValLen, &cTs, &cTsLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, errors.New(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), NewSlice(cTs, cTsLen), nil
+}
+
+// GetCFWithTS returns the data and the timestamp associated with the key from
+// the database and column family.
+func (db *DB) GetCFWithTS(opts *ReadOptions, cf *ColumnFamilyHandle, key []byte) (value *Slice, ts *Slice, err error) {
+	var (
+		cErr    *C.char
+		cValLen C.size_t
+		cTs     *C.char
+		cTsLen  C.size_t
+		cKey    = byteToChar(key)
+	)
+	cValue := C.rocksdb_get_cf_with_ts(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), &cValLen, &cTs, &cTsLen, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, errors.New(C.GoString(cErr))
+	}
+	return NewSlice(cValue, cValLen), NewSlice(cTs, cTsLen), nil
+}
+
+// PutWithTS writes data associated with a key and a timestamp to the database.
+// The database must use a TimestampComparator.
+func (db *DB) PutWithTS(opts *WriteOptions, key, ts, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cTs    = byteToChar(ts)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_put_with_ts(db.c, opts.c, cKey, C.size_t(len(key)), cTs, C.size_t(len(ts)), cValue, C.size_t(len(value)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// PutCFWithTS writes data associated with a key and a timestamp to the
+// database and column family.
+func (db *DB) PutCFWithTS(opts *WriteOptions, cf *ColumnFamilyHandle, key, ts, value []byte) error {
+	var (
+		cErr   *C.char
+		cKey   = byteToChar(key)
+		cTs    = byteToChar(ts)
+		cValue = byteToChar(value)
+	)
+	C.rocksdb_put_cf_with_ts(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cTs, C.size_t(len(ts)), cValue, C.size_t(len(value)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DeleteWithTS removes the data associated with the key from the database
+// as of the given timestamp.
+func (db *DB) DeleteWithTS(opts *WriteOptions, key, ts []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+		cTs  = byteToChar(ts)
+	)
+	C.rocksdb_delete_with_ts(db.c, opts.c, cKey, C.size_t(len(key)), cTs, C.size_t(len(ts)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// DeleteCFWithTS removes the data associated with the key from the database
+// and column family as of the given timestamp.
+func (db *DB) DeleteCFWithTS(opts *WriteOptions, cf *ColumnFamilyHandle, key, ts []byte) error {
+	var (
+		cErr *C.char
+		cKey = byteToChar(key)
+		cTs  = byteToChar(ts)
+	)
+	C.rocksdb_delete_cf_with_ts(db.c, opts.c, cf.c, cKey, C.size_t(len(key)), cTs, C.size_t(len(ts)), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -358,7 +489,7 @@ func (db *DB) Write(opts *WriteOptions, batch *WriteBatch) error {
 	C.rocksdb_write(db.c, opts.c, batch.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
@@ -401,6 +532,74 @@ func (db *DB) GetPropertyCF(propName string, cf *ColumnFamilyHandle) string {
 	return C.GoString(cValue)
 }
 
+// GetIntProperty returns the value of a database property with an integer
+// value, such as PropertyNumBlobFiles. The result is false if the property
+// is unknown or has no integer value.
+func (db *DB) GetIntProperty(propName string) (uint64, bool) {
+	var cValue C.uint64_t
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	ok := C.rocksdb_property_int(db.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// GetIntPropertyCF returns the value of a column family property with an
+// integer value.
+func (db *DB) GetIntPropertyCF(propName string, cf *ColumnFamilyHandle) (uint64, bool) {
+	var cValue C.uint64_t
+	cProp := C.CString(propName)
+	defer C.free(unsafe.Pointer(cProp))
+	ok := C.rocksdb_property_int_cf(db.c, cf.c, cProp, &cValue) == 0
+	return uint64(cValue), ok
+}
+
+// SetOptions dynamically changes the mutable options of the default column
+// family, e.g. "write_buffer_size", "level0_file_num_compaction_trigger",
+// "target_file_size_base" or "disable_auto_compactions".
+// The options are given by their RocksDB names and values in the RocksDB
+// string format. An *OptionError is returned if a name is unknown or
+// immutable or if a value is invalid, in which case no option is changed.
+func (db *DB) SetOptions(opts map[string]string) error {
+	return db.setOptions(nil, opts)
+}
+
+// SetOptionsCF dynamically changes the mutable options of the column family.
+// See SetOptions for details.
+func (db *DB) SetOptionsCF(cf *ColumnFamilyHandle, opts map[string]string) error {
+	return db.setOptions(cf, opts)
+}
+
+func (db *DB) setOptions(cf *ColumnFamilyHandle, opts map[string]string) error {
+	if len(opts) == 0 {
+		return nil
+	}
+
+	cKeys := make([]*C.char, 0, len(opts))
+	cValues := make([]*C.char, 0, len(opts))
+	for k, v := range opts {
+		cKeys = append(cKeys, C.CString(k))
+		cValues = append(cValues, C.CString(v))
+	}
+	defer func() {
+		for i := range cKeys {
+			C.free(unsafe.Pointer(cKeys[i]))
+			C.free(unsafe.Pointer(cValues[i]))
+		}
+	}()
+
+	var cErr *C.char
+	if cf == nil {
+		C.rocksdb_set_options(db.c, C.int(len(cKeys)), &cKeys[0], &cValues[0], &cErr)
+	} else {
+		C.rocksdb_set_options_cf(db.c, cf.c, C.int(len(cKeys)), &cKeys[0], &cValues[0], &cErr)
+	}
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newOptionError(C.GoString(cErr))
+	}
+	return nil
+}
+
 // CreateColumnFamily create a new column family.
 func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandle, error) {
 	var (
@@ -413,6 +612,7 @@ func (db *DB) CreateColumnFamily(opts *Options, name string) (*ColumnFamilyHandl
 		defer C.free(unsafe.Pointer(cErr))
 		return nil, errors.New(C.GoString(cErr))
 	}
+	db.cfOpts = append(db.cfOpts, opts.Clone())
 	return NewNativeColumnFamilyHandle(cHandle), nil
 }
 
@@ -552,11 +752,36 @@ func (db *DB) Flush(opts *FlushOptions) error {
 	C.rocksdb_flush(db.c, opts.c, &cErr)
 	if cErr != nil {
 		defer C.free(unsafe.Pointer(cErr))
-		return errors.New(C.GoString(cErr))
+		return newWriteError(C.GoString(cErr))
 	}
 	return nil
 }
 
+// FlushWAL writes the buffered WAL data to the log file. It is only needed
+// when manual WAL flush is enabled in the options. If sync is true the
+// log file is also synced to disk.
+func (db *DB) FlushWAL(sync bool) error {
+	var cErr *C.char
+	C.rocksdb_flush_wal(db.c, boolToChar(sync), &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return newWriteError(C.GoString(cErr))
+	}
+	return nil
+}
+
+// SyncWAL syncs the WAL to disk. It is the same as FlushWAL(true); with
+// manual WAL flush enabled any buffered WAL data is written out first.
+func (db *DB) SyncWAL() error {
+	return db.FlushWAL(true)
+}
+
+// GetLatestSequenceNumber returns the sequence number of the most recent
+// transaction.
+func (db *DB) GetLatestSequenceNumber() uint64 {
+	return uint64(C.rocksdb_get_latest_sequence_number(db.c))
+}
+
 // DisableFileDeletions disables file deletions and should be used when backup the database.
 func (db *DB) DisableFileDeletions() error {
 	var cErr *C.char
@@ -588,9 +813,20 @@ func (db *DB) DeleteFile(name string) {
 	C.rocksdb_delete_file(db.c, cName)
 }
 
-// Close closes the database.
+// Close closes the database and frees the options it keeps. Calling it
+// again has no effect.
 func (db *DB) Close() {
+	if db.c == nil {
+		return
+	}
 	C.rocksdb_close(db.c)
+	db.opts.Destroy()
+	for _, opts := range db.cfOpts {
+		opts.Destroy()
+	}
+	db.c = nil
+	db.opts = nil
+	db.cfOpts = nil
 }
 
 // DestroyDb removes a database entirely, removing everything from the
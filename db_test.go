@@ -1,7 +1,11 @@
 package gorocksdb
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -12,6 +16,12 @@ func TestOpenDb(t *testing.T) {
 	defer db.Close()
 }
 
+func TestDBCloseTwice(t *testing.T) {
+	db := newTestDB(t, "TestDBCloseTwice", nil)
+	db.Close()
+	db.Close()
+}
+
 func TestDBCRUD(t *testing.T) {
 	db := newTestDB(t, "TestDBGet", nil)
 	defer db.Close()
@@ -47,6 +57,208 @@ func TestDBCRUD(t *testing.T) {
 	ensure.True(t, v3.Data() == nil)
 }
 
+func TestDBSetOptions(t *testing.T) {
+	db := newTestDB(t, "TestDBSetOptions", nil)
+	defer db.Close()
+
+	ensure.Nil(t, db.SetOptions(map[string]string{
+		"write_buffer_size":                  "67108864",
+		"level0_file_num_compaction_trigger": "8",
+		"disable_auto_compactions":           "true",
+	}))
+
+	err := db.SetOptions(map[string]string{"no_such_option": "1"})
+	optErr, ok := err.(*OptionError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, optErr.Cause, ErrUnknownOption)
+	ensure.True(t, errors.Is(err, ErrUnknownOption))
+	ensure.False(t, errors.Is(err, ErrInvalidOptionValue))
+
+	err = db.SetOptions(map[string]string{"write_buffer_size": "huge"})
+	optErr, ok = err.(*OptionError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, optErr.Cause, ErrInvalidOptionValue)
+}
+
+func TestOptionErrorMessages(t *testing.T) {
+	db := newTestDB(t, "TestOptionErrorMessages", nil)
+	defer db.Close()
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	cf, err := db.CreateColumnFamily(opts, "other")
+	ensure.Nil(t, err)
+	defer cf.Destroy()
+
+	// The cause is derived from RocksDB's messages, so they are pinned here
+	// to notice when a RocksDB release rewords them.
+	for _, c := range []struct {
+		name, value string
+		cause       error
+		message     string
+	}{
+		{"no_such_option", "1", ErrUnknownOption, "Invalid argument: Could not find option: : no_such_option"},
+		{"num_levels", "3", ErrUnknownOption, "Invalid argument: Option not changeable: num_levels"},
+		{"compression", "kNoSuchCompression", ErrInvalidOptionValue, "Invalid argument: Error parsing:: compression"},
+	} {
+		for _, err := range []error{
+			db.SetOptions(map[string]string{c.name: c.value}),
+			db.SetOptionsCF(cf, map[string]string{c.name: c.value}),
+		} {
+			optErr, ok := err.(*OptionError)
+			ensure.True(t, ok, c.name)
+			ensure.DeepEqual(t, optErr.Message, c.message)
+			ensure.DeepEqual(t, optErr.Cause, c.cause, c.name)
+			ensure.True(t, errors.Is(err, c.cause), c.name)
+		}
+	}
+}
+
+func TestNoSpaceError(t *testing.T) {
+	for _, c := range []struct {
+		msg     string
+		noSpace bool
+	}{
+		{"IO error: No space left on device: While appending to file: /db/000012.log: No space left on device", true},
+		{"IO error: While open a file for appending: /db/000012.log: Permission denied", false},
+		{"Corruption: block checksum mismatch", false},
+	} {
+		err := newWriteError(c.msg)
+		ensure.DeepEqual(t, err.Error(), c.msg)
+		_, ok := err.(*NoSpaceError)
+		ensure.DeepEqual(t, ok, c.noSpace, c.msg)
+	}
+}
+
+func TestBlobFiles(t *testing.T) {
+	db := newTestDB(t, "TestBlobFiles", func(opts *Options) {
+		opts.SetEnableBlobFiles(true)
+		opts.SetMinBlobSize(4 << 10)
+		opts.SetBlobFileSize(8 << 20)
+		opts.SetBlobCompressionType(NoCompression)
+		opts.SetEnableBlobGC(true)
+		opts.SetBlobGCAgeCutoff(0.5)
+		opts.SetBlobGCForceThreshold(0.8)
+		ensure.True(t, opts.GetEnableBlobFiles())
+		ensure.DeepEqual(t, opts.GetMinBlobSize(), uint64(4<<10))
+		ensure.DeepEqual(t, opts.GetBlobFileSize(), uint64(8<<20))
+		ensure.True(t, opts.GetEnableBlobGC())
+		ensure.DeepEqual(t, opts.GetBlobGCAgeCutoff(), 0.5)
+		ensure.DeepEqual(t, opts.GetBlobGCForceThreshold(), 0.8)
+	})
+	defer db.Close()
+
+	// values of 10 to 500 KB end up in blob files
+	wo := NewDefaultWriteOptions()
+	values := make(map[string][]byte)
+	for i := 0; i < 20; i++ {
+		key := fmt.Sprintf("key%02d", i)
+		values[key] = bytes.Repeat([]byte{byte(i)}, (10+25*i)<<10)
+		ensure.Nil(t, db.Put(wo, []byte(key), values[key]))
+	}
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	numBlobFiles, ok := db.GetIntProperty(PropertyNumBlobFiles)
+	ensure.True(t, ok)
+	ensure.True(t, numBlobFiles > 0)
+	blobSize, ok := db.GetIntProperty(PropertyLiveBlobFileSize)
+	ensure.True(t, ok)
+	ensure.True(t, blobSize > 0)
+	ensure.StringContains(t, db.GetProperty(PropertyBlobStats), "Number of blob files")
+
+	ro := NewDefaultReadOptions()
+	for key, want := range values {
+		v, err := db.GetBytes(ro, []byte(key))
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, v, want, key)
+	}
+}
+
+func TestWriteOptionsNoSlowdown(t *testing.T) {
+	// a write buffer manager which stalls writes as soon as a memtable
+	// exists makes every write wait
+	wbm := NewWriteBufferManager(1, true)
+	defer wbm.Destroy()
+	db := newTestDB(t, "TestWriteOptionsNoSlowdown", func(opts *Options) {
+		opts.SetWriteBufferManager(wbm)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	wo.SetNoSlowdown(true)
+	ensure.True(t, wo.GetNoSlowdown())
+	err := db.Put(wo, []byte("foo"), []byte("bar"))
+	ensure.NotNil(t, err)
+	ensure.StringContains(t, err.Error(), "incomplete")
+}
+
+func TestWriteOptionsLowPri(t *testing.T) {
+	// without automatic compactions, L0 files pile up and put pressure on
+	// compaction, but normal writes are not slowed down
+	db := newTestDB(t, "TestWriteOptionsLowPri", func(opts *Options) {
+		opts.SetDisableAutoCompactions(true)
+		opts.SetLevel0FileNumCompactionTrigger(2)
+		opts.SetLevel0SlowdownWritesTrigger(4)
+		opts.SetLevel0StopWritesTrigger(20)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	wo.SetNoSlowdown(true)
+	for i := 0; i < 5; i++ {
+		ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%d", i)), []byte("val")))
+		ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+	}
+	ensure.DeepEqual(t, db.GetProperty("rocksdb.num-files-at-level0"), "5")
+
+	// low priority writes are throttled while compactions fall behind
+	lowPri := NewDefaultWriteOptions()
+	defer lowPri.Destroy()
+	lowPri.SetLowPri(true)
+	lowPri.SetNoSlowdown(true)
+	ensure.True(t, lowPri.GetLowPri())
+	err := db.Put(lowPri, []byte("foo"), []byte("bar"))
+	ensure.NotNil(t, err)
+	ensure.DeepEqual(t, err.Error(), "Result incomplete: Low priority write stall")
+
+	ensure.Nil(t, db.Put(wo, []byte("foo"), []byte("bar")))
+	ro := NewDefaultReadOptions()
+	v, err := db.GetBytes(ro, []byte("foo"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v, []byte("bar"))
+}
+
+func TestWriteOptionsMemtableInsertHintPerBatch(t *testing.T) {
+	db := newTestDB(t, "TestWriteOptionsMemtableInsertHintPerBatch", func(opts *Options) {
+		opts.SetAllowConcurrentMemtableWrites(false)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	wo.SetMemtableInsertHintPerBatch(true)
+	ensure.True(t, wo.GetMemtableInsertHintPerBatch())
+
+	// sequential and unordered keys are inserted correctly with hints
+	wb := NewWriteBatch()
+	defer wb.Destroy()
+	for _, i := range []int{1, 2, 3, 10, 5, 4, 20} {
+		wb.Put([]byte(fmt.Sprintf("key%02d", i)), []byte("val"))
+	}
+	ensure.Nil(t, db.Write(wo, wb))
+
+	ro := NewDefaultReadOptions()
+	iter := db.NewIterator(ro)
+	defer iter.Close()
+	var keys []string
+	for iter.SeekToFirst(); iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key().Data()))
+	}
+	ensure.Nil(t, iter.Err())
+	ensure.DeepEqual(t, keys, []string{"key01", "key02", "key03", "key04", "key05", "key10", "key20"})
+}
+
 func newTestDB(t *testing.T, name string, applyOpts func(opts *Options)) *DB {
 	dir, err := ioutil.TempDir("", "gorocksdb-"+name)
 	ensure.Nil(t, err)
@@ -61,3 +273,40 @@ func newTestDB(t *testing.T, name string, applyOpts func(opts *Options)) *DB {
 
 	return db
 }
+
+func TestManualWALFlush(t *testing.T) {
+	walDir, err := ioutil.TempDir("", "gorocksdb-TestManualWALFlush-wal")
+	ensure.Nil(t, err)
+	db := newTestDB(t, "TestManualWALFlush", func(opts *Options) {
+		opts.SetWalDir(walDir)
+		opts.SetManualWALFlush(true)
+		ensure.True(t, opts.GetManualWALFlush())
+	})
+	defer db.Close()
+
+	// walSize returns the size of the WAL files on disk
+	walSize := func() int64 {
+		infos, err := ioutil.ReadDir(walDir)
+		ensure.Nil(t, err)
+		var size int64
+		for _, info := range infos {
+			if filepath.Ext(info.Name()) == ".log" {
+				size += info.Size()
+			}
+		}
+		return size
+	}
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, db.Put(wo, []byte("foo"), []byte("bar")))
+	ensure.Nil(t, db.Put(wo, []byte("baz"), []byte("qux")))
+	ensure.DeepEqual(t, db.GetLatestSequenceNumber(), uint64(2))
+
+	// the writes are still buffered in memory
+	ensure.DeepEqual(t, walSize(), int64(0))
+
+	ensure.Nil(t, db.FlushWAL(false))
+	ensure.Nil(t, db.SyncWAL())
+	ensure.True(t, walSize() > 0)
+}
@@ -67,6 +67,19 @@ amount of bits in the filter to use per key in your database.
 If you're using a custom comparator in your code, be aware you may have to
 make your own filter policy object.
 
+Writes fail with a *NoSpaceError when the disk is full, so that it can be told
+apart from other errors:
+
+	if _, ok := err.(*gorocksdb.NoSpaceError); ok {
+		// free disk space
+	}
+
+The package wraps the RocksDB C API, plus a few C shims on top of it in
+gorocksdb.c. Features of the C++ API which the C API lacks are not supported.
+Notably there is no SstFileManager, so there is no maximum allowed space, no
+rate limit for deleting trash files, and neither IsMaxAllowedSpaceReached nor
+GetTotalSize.
+
 This documentation is not a complete discussion of RocksDB. Please read the
 RocksDB documentation <http://rocksdb.org/> for information on its
 operation. You'll find lots of goodies there.
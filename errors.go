@@ -0,0 +1,88 @@
+package gorocksdb
+
+import (
+	"errors"
+	"strings"
+)
+
+// Causes of an OptionError.
+var (
+	// ErrUnknownOption indicates that an option name is unknown or that the
+	// option can't be changed on an open database.
+	ErrUnknownOption = errors.New("unknown or immutable option")
+	// ErrInvalidOptionValue indicates that an option value can't be parsed
+	// or is out of range.
+	ErrInvalidOptionValue = errors.New("invalid option value")
+)
+
+// OptionError is returned when RocksDB rejects options given by name,
+// e.g. by DB.SetOptions. Use errors.Is to check its cause.
+//
+// RocksDB reports both causes with the same status code, so the cause is
+// recovered by matching fragments of RocksDB's message text.
+type OptionError struct {
+	// Cause is either ErrUnknownOption or ErrInvalidOptionValue.
+	Cause error
+	// Message is the error message reported by RocksDB.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *OptionError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns the cause of the error.
+func (e *OptionError) Unwrap() error {
+	return e.Cause
+}
+
+// unknownOptionMessages are fragments of the RocksDB status messages which
+// are reported for unknown or immutable option names. RocksDB doesn't give
+// these errors a distinct status code, so TestOptionErrorMessages pins the
+// full messages of the supported RocksDB version.
+var unknownOptionMessages = []string{
+	"Unrecognized option",
+	"Could not find option",
+	"Unable to parse the specified CF option",
+	"not changeable",
+	"not mutable",
+}
+
+// newOptionError creates an OptionError from a RocksDB error message.
+func newOptionError(msg string) error {
+	cause := ErrInvalidOptionValue
+	for _, m := range unknownOptionMessages {
+		if strings.Contains(msg, m) {
+			cause = ErrUnknownOption
+			break
+		}
+	}
+	return &OptionError{Cause: cause, Message: msg}
+}
+
+// NoSpaceError is returned when the disk is full, e.g. by DB.Put or
+// DB.Flush. Once it happened, RocksDB fails further writes with this error
+// until space was freed and the database was reopened.
+type NoSpaceError struct {
+	// Message is the error message reported by RocksDB.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *NoSpaceError) Error() string {
+	return e.Message
+}
+
+// noSpaceMessage is the message of the RocksDB status for a full disk. The
+// C API only passes on the message.
+const noSpaceMessage = "No space left on device"
+
+// newWriteError creates the error for a RocksDB error message reported by
+// an operation that writes to disk.
+func newWriteError(msg string) error {
+	if strings.Contains(msg, noSpaceMessage) {
+		return &NoSpaceError{Message: msg}
+	}
+	return errors.New(msg)
+}
@@ -5,6 +5,13 @@ import "C"
 
 // FilterPolicy is a factory type that allows the RocksDB database to create a
 // filter, such as a bloom filter, which will used to reduce reads.
+//
+// Filter policies implemented in Go create filters in the legacy
+// block-based format, with one filter per data block, and can only be used
+// with RocksDB releases which still support that format. RocksDB 7.0
+// removed block-based filters and rocksdb_filterpolicy_create from its
+// C API. Use NewBloomFilterFull, NewRibbonFilter or NewRibbonHybridFilter
+// for full and partitioned filters.
 type FilterPolicy interface {
 	// keys contains a list of keys (potentially with duplicates)
 	// that are ordered according to the user supplied comparator.
@@ -45,16 +52,41 @@ func (fp nativeFilterPolicy) Name() string                               { retur
 // ignores trailing spaces, it would be incorrect to use a
 // FilterPolicy (like NewBloomFilterPolicy) that does not ignore
 // trailing spaces in keys.
+//
+// RocksDB 7.0 and newer create a full filter like NewBloomFilterFull.
 func NewBloomFilter(bitsPerKey int) FilterPolicy {
 	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_bloom(C.int(bitsPerKey)))
 }
 
-// Hold references to filter policies.
-var filterPolicies []FilterPolicy
+// NewBloomFilterFull returns a new filter policy that uses a bloom filter
+// in the full format, with one filter per table file instead of one per
+// data block. bitsPerKey may be fractional, 10 yields a filter with ~1%
+// false positive rate. Full filters can be partitioned, see
+// BlockBasedTableOptions.SetPartitionFilters.
+func NewBloomFilterFull(bitsPerKey float64) FilterPolicy {
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_bloom_full(C.double(bitsPerKey)))
+}
+
+// NewRibbonFilter returns a new filter policy that uses a ribbon filter.
+// A ribbon filter needs ~30% less memory than a bloom filter with the same
+// false positive rate, but takes more CPU time to create.
+// bloomEquivalentBitsPerKey is the bits per key of a bloom filter with the
+// same false positive rate.
+func NewRibbonFilter(bloomEquivalentBitsPerKey float64) FilterPolicy {
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_ribbon(C.double(bloomEquivalentBitsPerKey)))
+}
+
+// NewRibbonHybridFilter returns a new filter policy that uses bloom filters
+// for levels lower than bloomBeforeLevel and ribbon filters from
+// bloomBeforeLevel onward. This saves memory in the large, rarely
+// written levels while flushes stay fast. A bloomBeforeLevel of -1 always
+// uses ribbon filters, 0 uses bloom filters for flushes only.
+func NewRibbonHybridFilter(bloomEquivalentBitsPerKey float64, bloomBeforeLevel int) FilterPolicy {
+	return NewNativeFilterPolicy(C.rocksdb_filterpolicy_create_ribbon_hybrid(C.double(bloomEquivalentBitsPerKey), C.int(bloomBeforeLevel)))
+}
 
-func registerFilterPolicy(fp FilterPolicy) int {
-	filterPolicies = append(filterPolicies, fp)
-	return len(filterPolicies) - 1
+func registerFilterPolicy(fp FilterPolicy) uintptr {
+	return handles.register(fp)
 }
 
 //export gorocksdb_filterpolicy_create_filter
@@ -66,7 +98,7 @@ func gorocksdb_filterpolicy_create_filter(idx int, cKeys **C.char, cKeysLen *C.s
 		keys[i] = charToByte(rawKeys[i], len)
 	}
 
-	dst := filterPolicies[idx].CreateFilter(keys)
+	dst := handles.get(uintptr(idx)).(FilterPolicy).CreateFilter(keys)
 	*cDstLen = C.size_t(len(dst))
 	return cByteSlice(dst)
 }
@@ -75,10 +107,10 @@ func gorocksdb_filterpolicy_create_filter(idx int, cKeys **C.char, cKeysLen *C.s
 func gorocksdb_filterpolicy_key_may_match(idx int, cKey *C.char, cKeyLen C.size_t, cFilter *C.char, cFilterLen C.size_t) C.uchar {
 	key := charToByte(cKey, cKeyLen)
 	filter := charToByte(cFilter, cFilterLen)
-	return boolToChar(filterPolicies[idx].KeyMayMatch(key, filter))
+	return boolToChar(handles.get(uintptr(idx)).(FilterPolicy).KeyMayMatch(key, filter))
 }
 
 //export gorocksdb_filterpolicy_name
 func gorocksdb_filterpolicy_name(idx int) *C.char {
-	return stringToChar(filterPolicies[idx].Name())
+	return stringToChar(handles.get(uintptr(idx)).(FilterPolicy).Name())
 }
@@ -1,6 +1,7 @@
 package gorocksdb
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -62,6 +63,42 @@ func TestFilterPolicy(t *testing.T) {
 	ensure.True(t, keyMayMatchCalled)
 }
 
+func TestNativeFilterPolicies(t *testing.T) {
+	for name, fp := range map[string]func() FilterPolicy{
+		"BloomFull":    func() FilterPolicy { return NewBloomFilterFull(10) },
+		"Ribbon":       func() FilterPolicy { return NewRibbonFilter(10) },
+		"RibbonHybrid": func() FilterPolicy { return NewRibbonHybridFilter(10, 1) },
+	} {
+		db := newTestDB(t, "TestNativeFilterPolicies"+name, func(opts *Options) {
+			bbto := NewDefaultBlockBasedTableOptions()
+			bbto.SetFilterPolicy(fp())
+			opts.SetBlockBasedTableFactory(bbto)
+		})
+
+		wo := NewDefaultWriteOptions()
+		for i := 0; i < 1000; i++ {
+			ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%04d", i)), []byte("val")))
+		}
+		ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+		// existing keys always pass the filter, missing keys are rejected
+		// apart from ~1% false positives
+		ro := NewDefaultReadOptions()
+		falsePositives := 0
+		for i := 0; i < 1000; i++ {
+			ensure.True(t, db.KeyMayExist(ro, []byte(fmt.Sprintf("key%04d", i))), name)
+			if db.KeyMayExist(ro, []byte(fmt.Sprintf("missing%04d", i))) {
+				falsePositives++
+			}
+		}
+		ensure.True(t, falsePositives < 50, name, falsePositives)
+
+		ro.Destroy()
+		wo.Destroy()
+		db.Close()
+	}
+}
+
 type mockFilterPolicy struct {
 	createFilter func(keys [][]byte) []byte
 	keyMayMatch  func(key, filter []byte) bool
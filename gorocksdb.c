@@ -1,9 +1,13 @@
+#include <stdio.h>
+#include <string.h>
 #include "gorocksdb.h"
 #include "_cgo_export.h"
 
 /* Base */
 
-void gorocksdb_destruct_handler(void* state) { }
+void gorocksdb_destruct_handler(void* state) {
+    gorocksdb_destruct((uintptr_t)state);
+}
 
 /* Comparator */
 
@@ -15,6 +19,128 @@ rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx) {
         (const char *(*)(void*))(gorocksdb_comparator_name));
 }
 
+rocksdb_comparator_t* gorocksdb_comparator_with_ts_create(uintptr_t idx, size_t ts_size) {
+    return rocksdb_comparator_with_ts_create(
+        (void*)idx,
+        gorocksdb_destruct_handler,
+        (int (*)(void*, const char*, size_t, const char*, size_t))(gorocksdb_comparator_compare),
+        (int (*)(void*, const char*, size_t, const char*, size_t))(gorocksdb_comparator_compare_ts),
+        (int (*)(void*, const char*, size_t, unsigned char, const char*, size_t, unsigned char))(gorocksdb_comparator_compare_without_ts),
+        (const char *(*)(void*))(gorocksdb_comparator_name),
+        ts_size);
+}
+
+/* Native Comparators */
+
+static void gorocksdb_comparator_destruct_noop(void* state) { }
+
+static int gorocksdb_bytewise_compare(const char* a, size_t alen, const char* b, size_t blen) {
+    int r = memcmp(a, b, alen < blen ? alen : blen);
+    if (r == 0) {
+        if (alen < blen) {
+            r = -1;
+        } else if (alen > blen) {
+            r = 1;
+        }
+    }
+    return r;
+}
+
+static int gorocksdb_compare_uint64(uint64_t x, uint64_t y) {
+    return x < y ? -1 : (x > y ? 1 : 0);
+}
+
+static uint64_t gorocksdb_decode_fixed64_be(const char* p) {
+    const unsigned char* b = (const unsigned char*)p;
+    uint64_t v = 0;
+    for (int i = 0; i < 8; i++) {
+        v = (v << 8) | b[i];
+    }
+    return v;
+}
+
+static uint64_t gorocksdb_decode_fixed64_le(const char* p) {
+    const unsigned char* b = (const unsigned char*)p;
+    uint64_t v = 0;
+    for (int i = 7; i >= 0; i--) {
+        v = (v << 8) | b[i];
+    }
+    return v;
+}
+
+static int gorocksdb_reverse_bytewise_compare(void* state, const char* a, size_t alen, const char* b, size_t blen) {
+    return -gorocksdb_bytewise_compare(a, alen, b, blen);
+}
+
+static const char* gorocksdb_reverse_bytewise_name(void* state) {
+    return "rocksdb.ReverseBytewiseComparator";
+}
+
+rocksdb_comparator_t* gorocksdb_reverse_bytewise_comparator_create(void) {
+    return rocksdb_comparator_create(
+        NULL,
+        gorocksdb_comparator_destruct_noop,
+        gorocksdb_reverse_bytewise_compare,
+        gorocksdb_reverse_bytewise_name);
+}
+
+static int gorocksdb_uint64_compare(void* state, const char* a, size_t alen, const char* b, size_t blen) {
+    if (alen != 8 || blen != 8) {
+        return gorocksdb_bytewise_compare(a, alen, b, blen);
+    }
+    return gorocksdb_compare_uint64(gorocksdb_decode_fixed64_be(a), gorocksdb_decode_fixed64_be(b));
+}
+
+static const char* gorocksdb_uint64_name(void* state) {
+    return "gorocksdb.Uint64Comparator";
+}
+
+rocksdb_comparator_t* gorocksdb_uint64_comparator_create(void) {
+    return rocksdb_comparator_create(
+        NULL,
+        gorocksdb_comparator_destruct_noop,
+        gorocksdb_uint64_compare,
+        gorocksdb_uint64_name);
+}
+
+static int gorocksdb_u64ts_compare_ts(void* state, const char* a, size_t alen, const char* b, size_t blen) {
+    return gorocksdb_compare_uint64(gorocksdb_decode_fixed64_le(a), gorocksdb_decode_fixed64_le(b));
+}
+
+static int gorocksdb_u64ts_compare_without_ts(void* state, const char* a, size_t alen, unsigned char a_has_ts, const char* b, size_t blen, unsigned char b_has_ts) {
+    if (a_has_ts) {
+        alen -= 8;
+    }
+    if (b_has_ts) {
+        blen -= 8;
+    }
+    return gorocksdb_bytewise_compare(a, alen, b, blen);
+}
+
+static int gorocksdb_u64ts_compare(void* state, const char* a, size_t alen, const char* b, size_t blen) {
+    int r = gorocksdb_u64ts_compare_without_ts(state, a, alen, 1, b, blen, 1);
+    if (r != 0) {
+        return r;
+    }
+    /* Newer entries, i.e. larger timestamps, come first. */
+    return -gorocksdb_u64ts_compare_ts(state, a + alen - 8, 8, b + blen - 8, 8);
+}
+
+static const char* gorocksdb_u64ts_name(void* state) {
+    return "leveldb.BytewiseComparator.u64ts";
+}
+
+rocksdb_comparator_t* gorocksdb_bytewise_comparator_with_u64ts_create(void) {
+    return rocksdb_comparator_with_ts_create(
+        NULL,
+        gorocksdb_comparator_destruct_noop,
+        gorocksdb_u64ts_compare,
+        gorocksdb_u64ts_compare_ts,
+        gorocksdb_u64ts_compare_without_ts,
+        gorocksdb_u64ts_name,
+        8);
+}
+
 /* CompactionFilter */
 
 rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx) {
@@ -25,6 +151,34 @@ rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx) {
         (const char *(*)(void*))(gorocksdb_compactionfilter_name));
 }
 
+static void gorocksdb_compactionfilter_destruct_noop(void* state) { }
+
+static unsigned char gorocksdb_remove_empty_value_filter(void* state, int level, const char* key, size_t key_length, const char* existing_value, size_t value_length, char** new_value, size_t* new_value_length, unsigned char* value_changed) {
+    return value_length == 0;
+}
+
+static const char* gorocksdb_remove_empty_value_name(void* state) {
+    return "RemoveEmptyValueCompactionFilter";
+}
+
+rocksdb_compactionfilter_t* gorocksdb_compactionfilter_remove_empty_value_create(void) {
+    return rocksdb_compactionfilter_create(
+        NULL,
+        gorocksdb_compactionfilter_destruct_noop,
+        gorocksdb_remove_empty_value_filter,
+        gorocksdb_remove_empty_value_name);
+}
+
+/* CompactionFilterFactory */
+
+rocksdb_compactionfilterfactory_t* gorocksdb_compactionfilterfactory_create(uintptr_t idx) {
+    return rocksdb_compactionfilterfactory_create(
+        (void*)idx,
+        gorocksdb_destruct_handler,
+        (rocksdb_compactionfilter_t* (*)(void*, rocksdb_compactionfiltercontext_t*))(gorocksdb_compactionfilterfactory_create_filter),
+        (const char *(*)(void*))(gorocksdb_compactionfilterfactory_name));
+}
+
 /* Filter Policy */
 
 rocksdb_filterpolicy_t* gorocksdb_filterpolicy_create(uintptr_t idx) {
@@ -64,3 +218,43 @@ rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx) {
     	(unsigned char (*)(void*, const char*, size_t))(gorocksdb_slicetransform_in_range),
     	(const char* (*)(void*))(gorocksdb_slicetransform_name));
 }
+
+typedef struct {
+    size_t cap_len;
+    char name[64];
+} gorocksdb_capped_prefix_t;
+
+static void gorocksdb_capped_prefix_destruct(void* state) {
+    free(state);
+}
+
+static char* gorocksdb_capped_prefix_transform(void* state, const char* key, size_t length, size_t* dst_length) {
+    size_t cap_len = ((gorocksdb_capped_prefix_t*)state)->cap_len;
+    *dst_length = length < cap_len ? length : cap_len;
+    return (char*)key;
+}
+
+static unsigned char gorocksdb_capped_prefix_in_domain(void* state, const char* key, size_t length) {
+    return 1;
+}
+
+static unsigned char gorocksdb_capped_prefix_in_range(void* state, const char* key, size_t length) {
+    return length <= ((gorocksdb_capped_prefix_t*)state)->cap_len;
+}
+
+static const char* gorocksdb_capped_prefix_name(void* state) {
+    return ((gorocksdb_capped_prefix_t*)state)->name;
+}
+
+rocksdb_slicetransform_t* gorocksdb_slicetransform_create_capped_prefix(size_t cap_len) {
+    gorocksdb_capped_prefix_t* state = malloc(sizeof(gorocksdb_capped_prefix_t));
+    state->cap_len = cap_len;
+    snprintf(state->name, sizeof(state->name), "rocksdb.CappedPrefix.%zu", cap_len);
+    return rocksdb_slicetransform_create(
+        state,
+        gorocksdb_capped_prefix_destruct,
+        gorocksdb_capped_prefix_transform,
+        gorocksdb_capped_prefix_in_domain,
+        gorocksdb_capped_prefix_in_range,
+        gorocksdb_capped_prefix_name);
+}
@@ -10,10 +10,19 @@ extern void gorocksdb_destruct_handler(void* state);
 /* CompactionFilter */
 
 extern rocksdb_compactionfilter_t* gorocksdb_compactionfilter_create(uintptr_t idx);
+extern rocksdb_compactionfilter_t* gorocksdb_compactionfilter_remove_empty_value_create(void);
+
+/* CompactionFilterFactory */
+
+extern rocksdb_compactionfilterfactory_t* gorocksdb_compactionfilterfactory_create(uintptr_t idx);
 
 /* Comparator */
 
 extern rocksdb_comparator_t* gorocksdb_comparator_create(uintptr_t idx);
+extern rocksdb_comparator_t* gorocksdb_comparator_with_ts_create(uintptr_t idx, size_t ts_size);
+extern rocksdb_comparator_t* gorocksdb_reverse_bytewise_comparator_create(void);
+extern rocksdb_comparator_t* gorocksdb_uint64_comparator_create(void);
+extern rocksdb_comparator_t* gorocksdb_bytewise_comparator_with_u64ts_create(void);
 
 /* Filter Policy */
 
@@ -28,3 +37,4 @@ extern void gorocksdb_mergeoperator_delete_value(void* state, const char* v, siz
 /* Slice Transform */
 
 extern rocksdb_slicetransform_t* gorocksdb_slicetransform_create(uintptr_t idx);
+extern rocksdb_slicetransform_t* gorocksdb_slicetransform_create_capped_prefix(size_t cap_len);
@@ -74,6 +74,17 @@ func (iter *Iterator) Value() *Slice {
 	return &Slice{cVal, cLen, true}
 }
 
+// Timestamp returns the timestamp of the entry the iterator currently
+// holds. It is only set if the database uses a TimestampComparator.
+func (iter *Iterator) Timestamp() *Slice {
+	var cLen C.size_t
+	cTs := C.rocksdb_iter_timestamp(iter.c, &cLen)
+	if cTs == nil {
+		return nil
+	}
+	return &Slice{cTs, cLen, true}
+}
+
 // Next moves the iterator to the next sequential key in the database.
 func (iter *Iterator) Next() {
 	C.rocksdb_iter_next(iter.c)
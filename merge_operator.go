@@ -47,6 +47,60 @@ type MergeOperator interface {
 	Name() string
 }
 
+// A MultiMerger can be implemented by a MergeOperator to combine a list
+// of operands at once, instead of one PartialMerge call per pair of
+// operands. This avoids building intermediate results, e.g. for appends.
+// The number of operands can be bounded with Options.SetMaxSuccessiveMerges.
+type MultiMerger interface {
+	// PartialMergeMulti combines at least two operands into a single
+	// merge operation, like PartialMerge does for two.
+	//
+	// If it is impossible or infeasible to combine the operations, return false.
+	PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool)
+}
+
+// An AssociativeMergeOperator is a simpler MergeOperator for merges which
+// are associative, e.g. numeric addition or string concatenation. Merging
+// two operands is the same as merging an operand into an existing value.
+type AssociativeMergeOperator interface {
+	// Merge merges value into existingValue and returns the result.
+	// existingValue is nil if the key does not exist before this op.
+	//
+	// Return true on success. If false is returned, the merge is treated
+	// as an error by the library.
+	Merge(key, existingValue, value []byte) ([]byte, bool)
+
+	// The name of the MergeOperator.
+	Name() string
+}
+
+// NewAssociativeMergeOperator creates a MergeOperator from an
+// AssociativeMergeOperator. FullMerge folds the operands into the existing
+// value from left to right and PartialMerge merges the right operand into
+// the left one.
+func NewAssociativeMergeOperator(op AssociativeMergeOperator) MergeOperator {
+	return associativeMergeOperator{op}
+}
+
+type associativeMergeOperator struct {
+	op AssociativeMergeOperator
+}
+
+func (mo associativeMergeOperator) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	value := existingValue
+	for _, operand := range operands {
+		var ok bool
+		if value, ok = mo.op.Merge(key, value, operand); !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+func (mo associativeMergeOperator) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return mo.op.Merge(key, leftOperand, rightOperand)
+}
+func (mo associativeMergeOperator) Name() string { return mo.op.Name() }
+
 // NewNativeMergeOperator creates a MergeOperator object.
 func NewNativeMergeOperator(c *C.rocksdb_mergeoperator_t) MergeOperator {
 	return nativeMergeOperator{c}
@@ -64,12 +118,8 @@ func (mo nativeMergeOperator) PartialMerge(key, leftOperand, rightOperand []byte
 }
 func (mo nativeMergeOperator) Name() string { return "" }
 
-// Hold references to merge operators.
-var mergeOperators []MergeOperator
-
-func registerMergeOperator(merger MergeOperator) int {
-	mergeOperators = append(mergeOperators, merger)
-	return len(mergeOperators) - 1
+func registerMergeOperator(merger MergeOperator) uintptr {
+	return handles.register(merger)
 }
 
 //export gorocksdb_mergeoperator_full_merge
@@ -83,7 +133,7 @@ func gorocksdb_mergeoperator_full_merge(idx int, cKey *C.char, cKeyLen C.size_t,
 		operands[i] = charToByte(rawOperands[i], len)
 	}
 
-	newValue, success := mergeOperators[idx].FullMerge(key, existingValue, operands)
+	newValue, success := handles.get(uintptr(idx)).(MergeOperator).FullMerge(key, existingValue, operands)
 	newValueLen := len(newValue)
 
 	*cNewValueLen = C.size_t(newValueLen)
@@ -102,27 +152,32 @@ func gorocksdb_mergeoperator_partial_merge_multi(idx int, cKey *C.char, cKeyLen
 		operands[i] = charToByte(rawOperands[i], len)
 	}
 
-	var newValue []byte
-	success := true
+	newValue, success := partialMergeMulti(handles.get(uintptr(idx)).(MergeOperator), key, operands)
+	newValueLen := len(newValue)
+	*cNewValueLen = C.size_t(newValueLen)
+	*cSuccess = boolToChar(success)
+
+	return cByteSlice(newValue)
+}
 
-	merger := mergeOperators[idx]
+// partialMergeMulti combines the operands with PartialMergeMulti if merger
+// is a MultiMerger and with pairwise PartialMerge calls otherwise.
+func partialMergeMulti(merger MergeOperator, key []byte, operands [][]byte) ([]byte, bool) {
+	if multi, ok := merger.(MultiMerger); ok {
+		return multi.PartialMergeMulti(key, operands)
+	}
 	leftOperand := operands[0]
-	for i := 1; i < int(cNumOperands); i++ {
-		newValue, success = merger.PartialMerge(key, leftOperand, operands[i])
+	for _, rightOperand := range operands[1:] {
+		newValue, success := merger.PartialMerge(key, leftOperand, rightOperand)
 		if !success {
-			break
+			return nil, false
 		}
 		leftOperand = newValue
 	}
-
-	newValueLen := len(newValue)
-	*cNewValueLen = C.size_t(newValueLen)
-	*cSuccess = boolToChar(success)
-
-	return cByteSlice(newValue)
+	return leftOperand, true
 }
 
 //export gorocksdb_mergeoperator_name
 func gorocksdb_mergeoperator_name(idx int) *C.char {
-	return stringToChar(mergeOperators[idx].Name())
+	return stringToChar(handles.get(uintptr(idx)).(MergeOperator).Name())
 }
@@ -1,6 +1,7 @@
 package gorocksdb
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/facebookgo/ensure"
@@ -40,6 +41,111 @@ func TestMergeOperator(t *testing.T) {
 	ensure.DeepEqual(t, v1.Data(), givenMerged)
 }
 
+func TestAssociativeMergeOperator(t *testing.T) {
+	merger := NewAssociativeMergeOperator(&mockAssociativeMergeOperator{
+		merge: func(key, existingValue, value []byte) ([]byte, bool) {
+			if len(value) == 0 {
+				return nil, false
+			}
+			return append(append([]byte{}, existingValue...), value...), true
+		},
+	})
+
+	v, ok := merger.FullMerge(nil, nil, [][]byte{[]byte("a"), []byte("b"), []byte("c")})
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, []byte("abc"))
+	v, ok = merger.PartialMerge(nil, []byte("b"), []byte("c"))
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, []byte("bc"))
+	_, ok = merger.FullMerge(nil, []byte("a"), [][]byte{[]byte("b"), {}})
+	ensure.False(t, ok)
+
+	db := newTestDB(t, "TestAssociativeMergeOperator", func(opts *Options) {
+		opts.SetMergeOperator(merger)
+	})
+	defer db.Close()
+
+	givenKey := []byte("hello")
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, givenKey, []byte("foo")))
+	ensure.Nil(t, db.Merge(wo, givenKey, []byte("bar")))
+	ensure.Nil(t, db.Merge(wo, givenKey, []byte("baz")))
+
+	// trigger a compaction to ensure that a merge is performed
+	db.CompactRange(Range{nil, nil})
+
+	ro := NewDefaultReadOptions()
+	v1, err := db.Get(ro, givenKey)
+	defer v1.Free()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v1.Data(), []byte("foobarbaz"))
+}
+
+type mockAssociativeMergeOperator struct {
+	merge func(key, existingValue, value []byte) ([]byte, bool)
+}
+
+func (m *mockAssociativeMergeOperator) Name() string { return "gorocksdb.test" }
+func (m *mockAssociativeMergeOperator) Merge(key, existingValue, value []byte) ([]byte, bool) {
+	return m.merge(key, existingValue, value)
+}
+
+func TestPartialMergeMulti(t *testing.T) {
+	operands := [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+
+	v, ok := partialMergeMulti(&appendMergeOperator{}, nil, operands)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, []byte("abc"))
+
+	multi := &multiAppendMergeOperator{}
+	v, ok = partialMergeMulti(multi, nil, operands)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, []byte("abc"))
+	ensure.DeepEqual(t, multi.calls, 1)
+}
+
+func benchmarkPartialMergeMulti(b *testing.B, merger MergeOperator) {
+	operands := make([][]byte, 100)
+	for i := range operands {
+		operands[i] = bytes.Repeat([]byte{byte(i)}, 64)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := partialMergeMulti(merger, nil, operands); !ok {
+			b.Fatal("merge failed")
+		}
+	}
+}
+
+func BenchmarkPartialMergePairwise(b *testing.B) {
+	benchmarkPartialMergeMulti(b, &appendMergeOperator{})
+}
+
+func BenchmarkPartialMergeMulti(b *testing.B) {
+	benchmarkPartialMergeMulti(b, &multiAppendMergeOperator{})
+}
+
+type appendMergeOperator struct{}
+
+func (m *appendMergeOperator) Name() string { return "gorocksdb.append" }
+func (m *appendMergeOperator) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	return bytes.Join(append([][]byte{existingValue}, operands...), nil), true
+}
+func (m *appendMergeOperator) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return bytes.Join([][]byte{leftOperand, rightOperand}, nil), true
+}
+
+type multiAppendMergeOperator struct {
+	appendMergeOperator
+	calls int
+}
+
+func (m *multiAppendMergeOperator) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	m.calls++
+	return bytes.Join(operands, nil), true
+}
+
 type mockMergeOperator struct {
 	fullMerge    func(key, existingValue []byte, operands [][]byte) ([]byte, bool)
 	partialMerge func(key, leftOperand, rightOperand []byte) ([]byte, bool)
@@ -0,0 +1,223 @@
+package mergeops
+
+import "encoding/binary"
+
+// EncodeUint64 encodes v as 8 byte little-endian value, as used by Uint64Add.
+func EncodeUint64(v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, v)
+	return buf
+}
+
+// DecodeUint64 decodes a value encoded by EncodeUint64.
+func DecodeUint64(b []byte) (uint64, bool) {
+	if len(b) != 8 {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint64(b), true
+}
+
+// EncodeInt64 encodes v as 8 byte little-endian two's complement value,
+// as used by Int64Add.
+func EncodeInt64(v int64) []byte {
+	return EncodeUint64(uint64(v))
+}
+
+// DecodeInt64 decodes a value encoded by EncodeInt64.
+func DecodeInt64(b []byte) (int64, bool) {
+	v, ok := DecodeUint64(b)
+	return int64(v), ok
+}
+
+// EncodeUvarint encodes v as varint, as used by UvarintAdd.
+func EncodeUvarint(v uint64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutUvarint(buf, v)]
+}
+
+// DecodeUvarint decodes a value encoded by EncodeUvarint.
+func DecodeUvarint(b []byte) (uint64, bool) {
+	v, n := binary.Uvarint(b)
+	return v, n > 0 && n == len(b)
+}
+
+// EncodeVarint encodes v as zig-zag varint, as used by VarintAdd.
+func EncodeVarint(v int64) []byte {
+	buf := make([]byte, binary.MaxVarintLen64)
+	return buf[:binary.PutVarint(buf, v)]
+}
+
+// DecodeVarint decodes a value encoded by EncodeVarint.
+func DecodeVarint(b []byte) (int64, bool) {
+	v, n := binary.Varint(b)
+	return v, n > 0 && n == len(b)
+}
+
+// Uint64Add is a merge operator which adds unsigned 64 bit integers encoded
+// by EncodeUint64. Sums wrap around on overflow. The encoding and the name
+// match the uint64add merge operator of RocksDB.
+type Uint64Add struct{}
+
+// NewUint64Add creates a Uint64Add merge operator.
+func NewUint64Add() *Uint64Add {
+	return &Uint64Add{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (Uint64Add) Name() string { return "uint64add" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op Uint64Add) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var sum uint64
+	if existingValue != nil {
+		v, ok := DecodeUint64(existingValue)
+		if !ok {
+			return nil, false
+		}
+		sum = v
+	}
+	for _, operand := range operands {
+		v, ok := DecodeUint64(operand)
+		if !ok {
+			return nil, false
+		}
+		sum += v
+	}
+	return EncodeUint64(sum), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op Uint64Add) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, [][]byte{leftOperand, rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op Uint64Add) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, operands)
+}
+
+// Int64Add is a merge operator which adds signed 64 bit integers encoded
+// by EncodeInt64. Sums wrap around on overflow.
+type Int64Add struct{}
+
+// NewInt64Add creates a Int64Add merge operator.
+func NewInt64Add() *Int64Add {
+	return &Int64Add{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (Int64Add) Name() string { return "gorocksdb.int64add" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op Int64Add) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var sum int64
+	if existingValue != nil {
+		v, ok := DecodeInt64(existingValue)
+		if !ok {
+			return nil, false
+		}
+		sum = v
+	}
+	for _, operand := range operands {
+		v, ok := DecodeInt64(operand)
+		if !ok {
+			return nil, false
+		}
+		sum += v
+	}
+	return EncodeInt64(sum), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op Int64Add) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, [][]byte{leftOperand, rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op Int64Add) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, operands)
+}
+
+// UvarintAdd is a merge operator which adds unsigned 64 bit integers
+// encoded by EncodeUvarint. Sums wrap around on overflow.
+type UvarintAdd struct{}
+
+// NewUvarintAdd creates a UvarintAdd merge operator.
+func NewUvarintAdd() *UvarintAdd {
+	return &UvarintAdd{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (UvarintAdd) Name() string { return "gorocksdb.uvarintadd" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op UvarintAdd) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var sum uint64
+	if existingValue != nil {
+		v, ok := DecodeUvarint(existingValue)
+		if !ok {
+			return nil, false
+		}
+		sum = v
+	}
+	for _, operand := range operands {
+		v, ok := DecodeUvarint(operand)
+		if !ok {
+			return nil, false
+		}
+		sum += v
+	}
+	return EncodeUvarint(sum), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op UvarintAdd) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, [][]byte{leftOperand, rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op UvarintAdd) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, operands)
+}
+
+// VarintAdd is a merge operator which adds signed 64 bit integers encoded
+// by EncodeVarint. Sums wrap around on overflow.
+type VarintAdd struct{}
+
+// NewVarintAdd creates a VarintAdd merge operator.
+func NewVarintAdd() *VarintAdd {
+	return &VarintAdd{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (VarintAdd) Name() string { return "gorocksdb.varintadd" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op VarintAdd) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var sum int64
+	if existingValue != nil {
+		v, ok := DecodeVarint(existingValue)
+		if !ok {
+			return nil, false
+		}
+		sum = v
+	}
+	for _, operand := range operands {
+		v, ok := DecodeVarint(operand)
+		if !ok {
+			return nil, false
+		}
+		sum += v
+	}
+	return EncodeVarint(sum), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op VarintAdd) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, [][]byte{leftOperand, rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op VarintAdd) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, operands)
+}
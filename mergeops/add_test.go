@@ -0,0 +1,50 @@
+package mergeops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestUint64Add(t *testing.T) {
+	op := NewUint64Add()
+	ensureMerge(t, op, nil, [][]byte{EncodeUint64(1), EncodeUint64(2), EncodeUint64(3)}, EncodeUint64(6))
+	ensureMerge(t, op, EncodeUint64(10), [][]byte{EncodeUint64(5)}, EncodeUint64(15))
+	ensureMerge(t, op, EncodeUint64(math.MaxUint64), [][]byte{EncodeUint64(2)}, EncodeUint64(1))
+
+	_, ok := op.FullMerge(nil, []byte("short"), [][]byte{EncodeUint64(1)})
+	ensure.False(t, ok)
+	_, ok = op.PartialMerge(nil, EncodeUint64(1), []byte("short"))
+	ensure.False(t, ok)
+}
+
+func TestInt64Add(t *testing.T) {
+	op := NewInt64Add()
+	ensureMerge(t, op, nil, [][]byte{EncodeInt64(1), EncodeInt64(-5), EncodeInt64(2)}, EncodeInt64(-2))
+	ensureMerge(t, op, EncodeInt64(-10), [][]byte{EncodeInt64(10)}, EncodeInt64(0))
+
+	v, ok := DecodeInt64(EncodeInt64(math.MinInt64))
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, v, int64(math.MinInt64))
+}
+
+func TestUvarintAdd(t *testing.T) {
+	op := NewUvarintAdd()
+	ensureMerge(t, op, nil, [][]byte{EncodeUvarint(1), EncodeUvarint(300), EncodeUvarint(1 << 40)}, EncodeUvarint(301+1<<40))
+	ensureMerge(t, op, EncodeUvarint(7), [][]byte{EncodeUvarint(0)}, EncodeUvarint(7))
+
+	_, ok := op.FullMerge(nil, nil, [][]byte{{0x80}})
+	ensure.False(t, ok)
+	_, ok = op.FullMerge(nil, nil, [][]byte{append(EncodeUvarint(1), 0)})
+	ensure.False(t, ok)
+}
+
+func TestVarintAdd(t *testing.T) {
+	op := NewVarintAdd()
+	ensureMerge(t, op, nil, [][]byte{EncodeVarint(-1), EncodeVarint(-300), EncodeVarint(100)}, EncodeVarint(-201))
+	ensureMerge(t, op, EncodeVarint(math.MaxInt64), [][]byte{EncodeVarint(1)}, EncodeVarint(math.MinInt64))
+
+	_, ok := op.PartialMerge(nil, []byte{}, EncodeVarint(1))
+	ensure.False(t, ok)
+}
@@ -0,0 +1,43 @@
+package mergeops
+
+// StringAppend is a merge operator which appends operands to the existing
+// value, separated by a delimiter.
+type StringAppend struct {
+	delim []byte
+}
+
+// NewStringAppend creates a StringAppend merge operator with the given
+// delimiter. An empty delimiter concatenates the values.
+func NewStringAppend(delim []byte) *StringAppend {
+	return &StringAppend{delim: append([]byte(nil), delim...)}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (op *StringAppend) Name() string { return "gorocksdb.stringappend" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op *StringAppend) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	size := len(existingValue)
+	for _, operand := range operands {
+		size += len(op.delim) + len(operand)
+	}
+	value := make([]byte, 0, size)
+	value = append(value, existingValue...)
+	for i, operand := range operands {
+		if existingValue != nil || i > 0 {
+			value = append(value, op.delim...)
+		}
+		value = append(value, operand...)
+	}
+	return value, true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op *StringAppend) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, leftOperand, [][]byte{rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op *StringAppend) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, operands[0], operands[1:])
+}
@@ -0,0 +1,13 @@
+package mergeops
+
+import "testing"
+
+func TestStringAppend(t *testing.T) {
+	op := NewStringAppend([]byte(", "))
+	ensureMerge(t, op, nil, [][]byte{[]byte("a"), []byte("b"), []byte("c")}, []byte("a, b, c"))
+	ensureMerge(t, op, []byte("x"), [][]byte{[]byte("a"), []byte("b")}, []byte("x, a, b"))
+	ensureMerge(t, op, []byte{}, [][]byte{[]byte("a")}, []byte(", a"))
+
+	op = NewStringAppend(nil)
+	ensureMerge(t, op, []byte("x"), [][]byte{[]byte("a"), []byte("b")}, []byte("xab"))
+}
@@ -0,0 +1,15 @@
+/*
+Package mergeops provides merge operators for common use cases, like counters
+and appends. All operators implement gorocksdb.MergeOperator and can be passed
+to Options.SetMergeOperator.
+
+	opts := gorocksdb.NewDefaultOptions()
+	opts.SetMergeOperator(mergeops.NewUint64Add())
+	db, err := gorocksdb.OpenDb(opts, "/path/to/db")
+	...
+	err = db.Merge(wo, []byte("counter"), mergeops.EncodeUint64(1))
+
+The operators fail a merge if a value or an operand can't be decoded, which
+RocksDB reports as a corruption error.
+*/
+package mergeops
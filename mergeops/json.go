@@ -0,0 +1,127 @@
+package mergeops
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// JSONMergePatch is a merge operator for JSON documents. Each operand is a
+// JSON merge patch as defined by RFC 7396: the fields of an object operand
+// are merged recursively into the value and null removes a field.
+//
+// Two patches can't be combined if the second one merges an object into a
+// field which the first one sets to another type, in that case PartialMerge
+// fails and RocksDB keeps both operands.
+type JSONMergePatch struct{}
+
+// NewJSONMergePatch creates a JSONMergePatch merge operator.
+func NewJSONMergePatch() *JSONMergePatch {
+	return &JSONMergePatch{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (JSONMergePatch) Name() string { return "gorocksdb.jsonmergepatch" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (JSONMergePatch) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	var doc interface{}
+	if existingValue != nil {
+		var ok bool
+		if doc, ok = decodeJSON(existingValue); !ok {
+			return nil, false
+		}
+	}
+	for _, operand := range operands {
+		patch, ok := decodeJSON(operand)
+		if !ok {
+			return nil, false
+		}
+		doc = applyPatch(doc, patch)
+	}
+	return encodeJSON(doc)
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (JSONMergePatch) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	left, ok := decodeJSON(leftOperand)
+	if !ok {
+		return nil, false
+	}
+	right, ok := decodeJSON(rightOperand)
+	if !ok {
+		return nil, false
+	}
+	patch, ok := combinePatches(left, right)
+	if !ok {
+		return nil, false
+	}
+	return encodeJSON(patch)
+}
+
+func decodeJSON(b []byte) (interface{}, bool) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil || dec.More() {
+		return nil, false
+	}
+	return v, true
+}
+
+func encodeJSON(v interface{}) ([]byte, bool) {
+	b, err := json.Marshal(v)
+	return b, err == nil
+}
+
+// applyPatch applies a merge patch to a document as described in RFC 7396.
+func applyPatch(doc, patch interface{}) interface{} {
+	patchObj, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	docObj, ok := doc.(map[string]interface{})
+	if !ok {
+		docObj = make(map[string]interface{}, len(patchObj))
+	}
+	for name, value := range patchObj {
+		if value == nil {
+			delete(docObj, name)
+		} else {
+			docObj[name] = applyPatch(docObj[name], value)
+		}
+	}
+	return docObj
+}
+
+// combinePatches returns a single patch which has the effect of applying
+// left and then right.
+func combinePatches(left, right interface{}) (interface{}, bool) {
+	rightObj, ok := right.(map[string]interface{})
+	if !ok {
+		return right, true
+	}
+	leftObj, ok := left.(map[string]interface{})
+	if !ok {
+		// left replaces the document, so the result is a replacement too,
+		// which can only be expressed if it is not an object.
+		return nil, false
+	}
+	for name, value := range rightObj {
+		leftValue, exists := leftObj[name]
+		if !exists {
+			leftObj[name] = value
+			continue
+		}
+		if _, isObj := value.(map[string]interface{}); isObj {
+			if _, leftIsObj := leftValue.(map[string]interface{}); !leftIsObj {
+				return nil, false
+			}
+		}
+		combined, ok := combinePatches(leftValue, value)
+		if !ok {
+			return nil, false
+		}
+		leftObj[name] = combined
+	}
+	return leftObj, true
+}
@@ -0,0 +1,29 @@
+package mergeops
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestJSONMergePatch(t *testing.T) {
+	op := NewJSONMergePatch()
+	ensureMerge(t, op,
+		[]byte(`{"a":"b","c":{"d":"e","f":"g"}}`),
+		[][]byte{[]byte(`{"a":"z","c":{"f":null}}`), []byte(`{"c":{"h":1}}`)},
+		[]byte(`{"a":"z","c":{"d":"e","h":1}}`))
+	ensureMerge(t, op, nil,
+		[][]byte{[]byte(`{"n":12345678901234567890}`), []byte(`{"l":[1,2]}`)},
+		[]byte(`{"l":[1,2],"n":12345678901234567890}`))
+	ensureMerge(t, op, []byte(`{"a":1}`), [][]byte{[]byte(`["replaced"]`)}, []byte(`["replaced"]`))
+
+	// an object can't be merged into a field the previous patch replaced
+	_, ok := op.PartialMerge(nil, []byte(`{"a":null}`), []byte(`{"a":{"b":1}}`))
+	ensure.False(t, ok)
+	v, ok := op.FullMerge(nil, []byte(`{"a":{"c":2}}`), [][]byte{[]byte(`{"a":null}`), []byte(`{"a":{"b":1,"d":null}}`)})
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, string(v), `{"a":{"b":1}}`)
+
+	_, ok = op.FullMerge(nil, []byte(`{"a":`), nil)
+	ensure.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package mergeops
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+	"github.com/tecbot/gorocksdb"
+)
+
+// ensureMerge checks that merging operands into existingValue results in
+// want, both with FullMerge alone and after partially merging each pair of
+// adjacent operands or all operands at once.
+func ensureMerge(t *testing.T, op gorocksdb.MergeOperator, existingValue []byte, operands [][]byte, want []byte) {
+	got, ok := op.FullMerge(nil, existingValue, operands)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, got, want)
+
+	for i := 0; i+1 < len(operands); i++ {
+		combined, ok := op.PartialMerge(nil, operands[i], operands[i+1])
+		ensure.True(t, ok)
+		partial := append(append(append([][]byte{}, operands[:i]...), combined), operands[i+2:]...)
+		got, ok := op.FullMerge(nil, existingValue, partial)
+		ensure.True(t, ok)
+		ensure.DeepEqual(t, got, want)
+	}
+
+	if multi, ok := op.(gorocksdb.MultiMerger); ok && len(operands) > 1 {
+		combined, ok := multi.PartialMergeMulti(nil, operands)
+		ensure.True(t, ok)
+		got, ok := op.FullMerge(nil, existingValue, [][]byte{combined})
+		ensure.True(t, ok)
+		ensure.DeepEqual(t, got, want)
+	}
+}
+
+func TestMergeOperatorsWithDB(t *testing.T) {
+	ops := []struct {
+		op       gorocksdb.MergeOperator
+		value    []byte
+		operands [][]byte
+		want     []byte
+	}{
+		{NewUint64Add(), EncodeUint64(1), [][]byte{EncodeUint64(2), EncodeUint64(3)}, EncodeUint64(6)},
+		{NewVarintAdd(), EncodeVarint(1), [][]byte{EncodeVarint(-2), EncodeVarint(-3)}, EncodeVarint(-4)},
+		{NewMax(), []byte("b"), [][]byte{[]byte("a"), []byte("c")}, []byte("c")},
+		{NewStringAppend([]byte(",")), []byte("a"), [][]byte{[]byte("b"), []byte("c")}, []byte("a,b,c")},
+		{NewSetUnion(), EncodeSet([][]byte{[]byte("b")}), [][]byte{EncodeSet([][]byte{[]byte("c"), []byte("a")})}, EncodeSet([][]byte{[]byte("a"), []byte("b"), []byte("c")})},
+		{NewJSONMergePatch(), []byte(`{"a":1}`), [][]byte{[]byte(`{"b":{"c":2}}`), []byte(`{"a":null}`)}, []byte(`{"b":{"c":2}}`)},
+	}
+	for _, tc := range ops {
+		dir, err := ioutil.TempDir("", "gorocksdb-mergeops-"+tc.op.Name())
+		ensure.Nil(t, err)
+
+		opts := gorocksdb.NewDefaultOptions()
+		opts.SetCreateIfMissing(true)
+		opts.SetMergeOperator(tc.op)
+		db, err := gorocksdb.OpenDb(opts, dir)
+		ensure.Nil(t, err)
+		opts.Destroy()
+
+		wo := gorocksdb.NewDefaultWriteOptions()
+		ro := gorocksdb.NewDefaultReadOptions()
+		key := []byte("key")
+		ensure.Nil(t, db.Put(wo, key, tc.value))
+		for _, operand := range tc.operands {
+			ensure.Nil(t, db.Merge(wo, key, operand))
+		}
+		db.CompactRange(gorocksdb.Range{})
+
+		value, err := db.GetBytes(ro, key)
+		ensure.Nil(t, err)
+		ensure.DeepEqual(t, value, tc.want)
+
+		wo.Destroy()
+		ro.Destroy()
+		db.Close()
+	}
+}
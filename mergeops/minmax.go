@@ -0,0 +1,60 @@
+package mergeops
+
+import "bytes"
+
+// Max is a merge operator which keeps the greatest value, compared
+// byte-wise. Use a big-endian encoding for numbers. The name matches the
+// max merge operator of RocksDB.
+type Max struct{}
+
+// NewMax creates a Max merge operator.
+func NewMax() *Max {
+	return &Max{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (Max) Name() string { return "MaxOperator" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (Max) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	return selectValue(existingValue, operands, 1), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (Max) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return selectValue(leftOperand, [][]byte{rightOperand}, 1), true
+}
+
+// Min is a merge operator which keeps the smallest value, compared
+// byte-wise. Use a big-endian encoding for numbers.
+type Min struct{}
+
+// NewMin creates a Min merge operator.
+func NewMin() *Min {
+	return &Min{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (Min) Name() string { return "gorocksdb.min" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (Min) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	return selectValue(existingValue, operands, -1), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (Min) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return selectValue(leftOperand, [][]byte{rightOperand}, -1), true
+}
+
+// selectValue returns the value for which bytes.Compare with all others
+// returns sign or 0. A nil existing value is ignored.
+func selectValue(existingValue []byte, operands [][]byte, sign int) []byte {
+	selected := existingValue
+	for _, operand := range operands {
+		if selected == nil || bytes.Compare(operand, selected) == sign {
+			selected = operand
+		}
+	}
+	return append([]byte{}, selected...)
+}
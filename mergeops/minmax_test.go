@@ -0,0 +1,17 @@
+package mergeops
+
+import "testing"
+
+func TestMax(t *testing.T) {
+	op := NewMax()
+	ensureMerge(t, op, nil, [][]byte{[]byte("b"), []byte("c"), []byte("a")}, []byte("c"))
+	ensureMerge(t, op, []byte("z"), [][]byte{[]byte("b"), []byte("y")}, []byte("z"))
+	ensureMerge(t, op, []byte{}, [][]byte{[]byte("a")}, []byte("a"))
+}
+
+func TestMin(t *testing.T) {
+	op := NewMin()
+	ensureMerge(t, op, nil, [][]byte{[]byte("b"), []byte("c"), []byte("a")}, []byte("a"))
+	ensureMerge(t, op, []byte("a"), [][]byte{[]byte("b"), []byte("aa")}, []byte("a"))
+	ensureMerge(t, op, []byte("a"), [][]byte{{}}, []byte{})
+}
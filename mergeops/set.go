@@ -0,0 +1,106 @@
+package mergeops
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sort"
+)
+
+// EncodeSet encodes a set of byte strings as used by SetUnion. The members
+// are sorted and deduplicated and each is prefixed by its varint length.
+func EncodeSet(members [][]byte) []byte {
+	sorted := make([][]byte, len(members))
+	copy(sorted, members)
+	sort.Slice(sorted, func(i, j int) bool { return bytes.Compare(sorted[i], sorted[j]) < 0 })
+	return encodeSorted(sorted)
+}
+
+// DecodeSet decodes a set encoded by EncodeSet.
+func DecodeSet(b []byte) ([][]byte, bool) {
+	var members [][]byte
+	for len(b) > 0 {
+		size, n := binary.Uvarint(b)
+		if n <= 0 || uint64(len(b)-n) < size {
+			return nil, false
+		}
+		members = append(members, b[n:n+int(size)])
+		b = b[n+int(size):]
+	}
+	return members, true
+}
+
+// encodeSorted encodes sorted members, skipping duplicates.
+func encodeSorted(members [][]byte) []byte {
+	size := 0
+	for _, m := range members {
+		size += binary.MaxVarintLen64 + len(m)
+	}
+	buf := make([]byte, 0, size)
+	var lenBuf [binary.MaxVarintLen64]byte
+	for i, m := range members {
+		if i > 0 && bytes.Equal(m, members[i-1]) {
+			continue
+		}
+		buf = append(buf, lenBuf[:binary.PutUvarint(lenBuf[:], uint64(len(m)))]...)
+		buf = append(buf, m...)
+	}
+	return buf
+}
+
+// SetUnion is a merge operator for sets of byte strings encoded by
+// EncodeSet. Merging computes the union of the value and all operands.
+type SetUnion struct{}
+
+// NewSetUnion creates a SetUnion merge operator.
+func NewSetUnion() *SetUnion {
+	return &SetUnion{}
+}
+
+// Name implements gorocksdb.MergeOperator.
+func (SetUnion) Name() string { return "gorocksdb.setunion" }
+
+// FullMerge implements gorocksdb.MergeOperator.
+func (op SetUnion) FullMerge(key, existingValue []byte, operands [][]byte) ([]byte, bool) {
+	union, ok := DecodeSet(existingValue)
+	if !ok {
+		return nil, false
+	}
+	for _, operand := range operands {
+		members, ok := DecodeSet(operand)
+		if !ok {
+			return nil, false
+		}
+		union = mergeSorted(union, members)
+	}
+	return encodeSorted(union), true
+}
+
+// PartialMerge implements gorocksdb.MergeOperator.
+func (op SetUnion) PartialMerge(key, leftOperand, rightOperand []byte) ([]byte, bool) {
+	return op.FullMerge(key, leftOperand, [][]byte{rightOperand})
+}
+
+// PartialMergeMulti implements gorocksdb.MultiMerger.
+func (op SetUnion) PartialMergeMulti(key []byte, operands [][]byte) ([]byte, bool) {
+	return op.FullMerge(key, nil, operands)
+}
+
+// mergeSorted merges two sorted lists, dropping duplicates.
+func mergeSorted(a, b [][]byte) [][]byte {
+	merged := make([][]byte, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch c := bytes.Compare(a[0], b[0]); {
+		case c < 0:
+			merged = append(merged, a[0])
+			a = a[1:]
+		case c > 0:
+			merged = append(merged, b[0])
+			b = b[1:]
+		default:
+			merged = append(merged, a[0])
+			a, b = a[1:], b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
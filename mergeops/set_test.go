@@ -0,0 +1,32 @@
+package mergeops
+
+import (
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestSetEncoding(t *testing.T) {
+	members, ok := DecodeSet(EncodeSet([][]byte{[]byte("b"), []byte("a"), []byte("b"), {}}))
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, members, [][]byte{{}, []byte("a"), []byte("b")})
+
+	_, ok = DecodeSet([]byte{5, 'a'})
+	ensure.False(t, ok)
+}
+
+func TestSetUnion(t *testing.T) {
+	op := NewSetUnion()
+	set := func(members ...string) []byte {
+		b := make([][]byte, len(members))
+		for i, m := range members {
+			b[i] = []byte(m)
+		}
+		return EncodeSet(b)
+	}
+	ensureMerge(t, op, nil, [][]byte{set("c", "a"), set("b"), set("a", "d")}, set("a", "b", "c", "d"))
+	ensureMerge(t, op, set("x", "a"), [][]byte{set(), set("a")}, set("a", "x"))
+
+	_, ok := op.PartialMerge(nil, set("a"), []byte{5, 'a'})
+	ensure.False(t, ok)
+}
@@ -3,7 +3,11 @@ package gorocksdb
 // #include "rocksdb/c.h"
 // #include "gorocksdb.h"
 import "C"
-import "unsafe"
+import (
+	"errors"
+	"sync/atomic"
+	"unsafe"
+)
 
 // CompressionType specifies the block compression.
 // DB contents are stored in a set of blocks, each of which holds a
@@ -20,6 +24,12 @@ const (
 	Bz2Compression    = CompressionType(C.rocksdb_bz2_compression)
 	LZ4Compression    = CompressionType(C.rocksdb_lz4_compression)
 	LZ4HCCompression  = CompressionType(C.rocksdb_lz4hc_compression)
+	XpressCompression = CompressionType(C.rocksdb_xpress_compression)
+	ZSTDCompression   = CompressionType(C.rocksdb_zstd_compression)
+
+	// DisableCompressionOption is only valid for SetBottommostCompression,
+	// the bottommost level then uses the compression of the other levels.
+	DisableCompressionOption = CompressionType(0xff)
 )
 
 // CompactionStyle specifies the compaction style.
@@ -62,12 +72,61 @@ type Options struct {
 	// Hold references for GC.
 	env  *Env
 	bbto *BlockBasedTableOptions
+	uco  *UniversalCompactionOptions
+	fco  *FIFOCompactionOptions
+	wbm  *WriteBufferManager
+	rl   *RateLimiter
+
+	// The values given to the callback setters.
+	cmp Comparator
+	mo  MergeOperator
+	cf  CompactionFilter
+	cff CompactionFilterFactory
+	st  SliceTransform
+
+	// The C API has no getter for these, so we keep the last value set.
+	compressionOpts                      *CompressionOptions
+	bottommostCompressionOpts            *CompressionOptions
+	maxBytesForLevelMultiplierAdditional []int
+	dbLogDir                             string
+	walDir                               string
+
+	// RocksDB only keeps raw pointers to these, so we free them once
+	// neither the Options, their clones nor a DB use them anymore.
+	// Merge operators and slice transforms are owned by RocksDB.
+	ccmp *cRef
+	ccf  *cRef
+
+	// The table and compaction options created by Config.Build, which
+	// the getters return. They are destroyed with the last Options
+	// using them.
+	cbuilt *cRef
+}
+
+// cRef is a reference counted C object.
+type cRef struct {
+	refs    int32
+	destroy func()
+}
+
+func newCRef(destroy func()) *cRef {
+	return &cRef{refs: 1, destroy: destroy}
+}
+
+// ref adds a reference. It is a no-op on nil.
+func (r *cRef) ref() *cRef {
+	if r != nil {
+		atomic.AddInt32(&r.refs, 1)
+	}
+	return r
+}
 
-	// We keep these so we can free their memory in Destroy.
-	ccmp *C.rocksdb_comparator_t
-	cmo  *C.rocksdb_mergeoperator_t
-	cst  *C.rocksdb_slicetransform_t
-	ccf  *C.rocksdb_compactionfilter_t
+// release removes a reference and destroys the C object with the last one.
+// It is a no-op on nil.
+func (r *cRef) release() {
+	if r != nil && atomic.AddInt32(&r.refs, -1) == 0 {
+		r.destroy()
+	}
 }
 
 // NewDefaultOptions creates the default Options.
@@ -80,6 +139,96 @@ func NewNativeOptions(c *C.rocksdb_options_t) *Options {
 	return &Options{c: c}
 }
 
+// Clone creates a copy of the Options. Callbacks, the env and the table
+// factory are shared with the copy, which must be destroyed separately.
+func (opts *Options) Clone() *Options {
+	cloned := *opts
+	cloned.c = C.rocksdb_options_create_copy(opts.c)
+	cloned.ccmp = opts.ccmp.ref()
+	cloned.ccf = opts.ccf.ref()
+	cloned.cbuilt = opts.cbuilt.ref()
+	return &cloned
+}
+
+// NewOptionsFromString creates a Options object from a RocksDB option string
+// like "write_buffer_size=64M;max_write_buffer_number=4". Options not present
+// in the string are taken from base, or from the defaults if base is nil.
+//
+// Nested options are given in braces, e.g.
+// "block_based_table_factory={block_size=16K;cache_index_and_filter_blocks=true}".
+//
+// Callbacks set on base are shared with the new Options. Getters that report
+// the last value set through this wrapper, like GetWalDir or
+// GetBlockBasedTableFactory, keep reporting the values of base even if the
+// string changes them.
+func NewOptionsFromString(base *Options, optsStr string) (*Options, error) {
+	if base == nil {
+		base = NewDefaultOptions()
+		defer base.Destroy()
+	}
+	cOptsStr := C.CString(optsStr)
+	defer C.free(unsafe.Pointer(cOptsStr))
+
+	var cErr *C.char
+	cOpts := C.rocksdb_options_create()
+	C.rocksdb_get_options_from_string(base.c, cOptsStr, cOpts, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		C.rocksdb_options_destroy(cOpts)
+		return nil, newOptionError(C.GoString(cErr))
+	}
+	opts := base.Clone()
+	C.rocksdb_options_destroy(opts.c)
+	opts.c = cOpts
+	return opts, nil
+}
+
+// LoadLatestOptions loads the options of the database at dbPath from its
+// latest OPTIONS file. It returns the DB options together with the names and
+// options of all column families, which can be passed to
+// OpenDbColumnFamilies as they are. All returned Options must be destroyed
+// by the caller.
+//
+// The block based tables of all column families share a new 8MB LRU block
+// cache, use SetBlockBasedTableFactory to configure another one.
+//
+// Getters that report the last value set through this wrapper, like
+// GetWalDir, return their zero values for the loaded Options.
+func LoadLatestOptions(dbPath string) (*Options, []string, []*Options, error) {
+	cPath := C.CString(dbPath)
+	defer C.free(unsafe.Pointer(cPath))
+	env := NewDefaultEnv()
+	defer env.Destroy()
+	cache := NewLRUCache(8 << 20)
+	defer cache.Destroy()
+
+	var (
+		cErr       *C.char
+		cDbOpts    *C.rocksdb_options_t
+		cNumCfs    C.size_t
+		cCfNames   **C.char
+		cCfOptsArr **C.rocksdb_options_t
+	)
+	C.rocksdb_load_latest_options(cPath, env.c, false, cache.c, &cDbOpts, &cNumCfs, &cCfNames, &cCfOptsArr, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		return nil, nil, nil, errors.New(C.GoString(cErr))
+	}
+	defer C.rocksdb_load_latest_options_destroy(cDbOpts, cCfNames, cCfOptsArr, cNumCfs)
+
+	numCfs := int(cNumCfs)
+	names := (*[1 << 30]*C.char)(unsafe.Pointer(cCfNames))[:numCfs:numCfs]
+	cCfOpts := (*[1 << 30]*C.rocksdb_options_t)(unsafe.Pointer(cCfOptsArr))[:numCfs:numCfs]
+	cfNames := make([]string, numCfs)
+	cfOpts := make([]*Options, numCfs)
+	for i := 0; i < numCfs; i++ {
+		cfNames[i] = C.GoString(names[i])
+		cfOpts[i] = NewNativeOptions(C.rocksdb_options_create_copy(cCfOpts[i]))
+	}
+	dbOpts := NewNativeOptions(C.rocksdb_options_create_copy(cDbOpts))
+	return dbOpts, cfNames, cfOpts, nil
+}
+
 // -------------------
 // Parameters that affect behavior
 
@@ -87,38 +236,72 @@ func NewNativeOptions(c *C.rocksdb_options_t) *Options {
 // which will be applied on compactions.
 // Default: nil
 func (opts *Options) SetCompactionFilter(value CompactionFilter) {
+	opts.cf = value
+	var ccf *C.rocksdb_compactionfilter_t
 	if nc, ok := value.(nativeCompactionFilter); ok {
-		opts.ccf = nc.c
+		ccf = nc.c
 	} else {
-		idx := registerCompactionFilter(value)
-		opts.ccf = C.gorocksdb_compactionfilter_create(C.uintptr_t(idx))
+		ccf = createCompactionFilter(value)
 	}
-	C.rocksdb_options_set_compaction_filter(opts.c, opts.ccf)
+	C.rocksdb_options_set_compaction_filter(opts.c, ccf)
+	opts.ccf.release()
+	opts.ccf = newCRef(func() { C.rocksdb_compactionfilter_destroy(ccf) })
+}
+
+// GetCompactionFilter returns the compaction filter, nil if none was set.
+func (opts *Options) GetCompactionFilter() CompactionFilter {
+	return opts.cf
 }
 
 // SetComparator sets the comparator which define the order of keys in the table.
+// If value is a TimestampComparator, keys carry a user-defined timestamp
+// and must be written with the WithTS variants of the write methods.
 // Default: a comparator that uses lexicographic byte-wise ordering
 func (opts *Options) SetComparator(value Comparator) {
-	if nc, ok := value.(nativeComparator); ok {
-		opts.ccmp = nc.c
-	} else {
+	opts.cmp = value
+	var ccmp *C.rocksdb_comparator_t
+	switch nc := value.(type) {
+	case nativeComparator:
+		ccmp = nc.cComparator()
+	case nativeTimestampComparator:
+		ccmp = nc.cComparator()
+	default:
 		idx := registerComperator(value)
-		opts.ccmp = C.gorocksdb_comparator_create(C.uintptr_t(idx))
+		if tc, ok := value.(TimestampComparator); ok {
+			ccmp = C.gorocksdb_comparator_with_ts_create(C.uintptr_t(idx), C.size_t(tc.TimestampSize()))
+		} else {
+			ccmp = C.gorocksdb_comparator_create(C.uintptr_t(idx))
+		}
 	}
-	C.rocksdb_options_set_comparator(opts.c, opts.ccmp)
+	C.rocksdb_options_set_comparator(opts.c, ccmp)
+	opts.ccmp.release()
+	opts.ccmp = newCRef(func() { C.rocksdb_comparator_destroy(ccmp) })
+}
+
+// GetComparator returns the comparator, nil if the default
+// byte-wise comparator is used.
+func (opts *Options) GetComparator() Comparator {
+	return opts.cmp
 }
 
 // SetMergeOperator sets the merge operator which will be called
 // if a merge operations are used.
 // Default: nil
 func (opts *Options) SetMergeOperator(value MergeOperator) {
+	opts.mo = value
+	var cmo *C.rocksdb_mergeoperator_t
 	if nmo, ok := value.(nativeMergeOperator); ok {
-		opts.cmo = nmo.c
+		cmo = nmo.c
 	} else {
 		idx := registerMergeOperator(value)
-		opts.cmo = C.gorocksdb_mergeoperator_create(C.uintptr_t(idx))
+		cmo = C.gorocksdb_mergeoperator_create(C.uintptr_t(idx))
 	}
-	C.rocksdb_options_set_merge_operator(opts.c, opts.cmo)
+	C.rocksdb_options_set_merge_operator(opts.c, cmo)
+}
+
+// GetMergeOperator returns the merge operator, nil if none was set.
+func (opts *Options) GetMergeOperator() MergeOperator {
+	return opts.mo
 }
 
 // A single CompactionFilter instance to call into during compaction.
@@ -141,16 +324,33 @@ func (opts *Options) SetMergeOperator(value MergeOperator) {
 //	C.rocksdb_options_set_compaction_filter(opts.c, value.filter)
 //}
 
-// This is a factory that provides compaction filter objects which allow
-// an application to modify/delete a key-value during background compaction.
+// SetCompactionFilterFactory sets the factory that provides compaction
+// filter objects which allow an application to modify/delete a key-value
+// during background compaction.
 //
-// A new filter will be created on each compaction run.  If multithreaded
+// A new filter will be created on each compaction run. If multithreaded
 // compaction is being used, each created CompactionFilter will only be used
 // from a single thread and so does not need to be thread-safe.
+// A compaction filter set with SetCompactionFilter takes precedence.
 //
 // Default: a factory that doesn't provide any object
-// std::shared_ptr<CompactionFilterFactory> compaction_filter_factory;
-// TODO: implement in C and Go
+func (opts *Options) SetCompactionFilterFactory(value CompactionFilterFactory) {
+	opts.cff = value
+	var ccff *C.rocksdb_compactionfilterfactory_t
+	if ncff, ok := value.(nativeCompactionFilterFactory); ok {
+		ccff = ncff.c
+	} else {
+		idx := registerCompactionFilterFactory(value)
+		ccff = C.gorocksdb_compactionfilterfactory_create(C.uintptr_t(idx))
+	}
+	C.rocksdb_options_set_compaction_filter_factory(opts.c, ccff)
+}
+
+// GetCompactionFilterFactory returns the compaction filter factory,
+// nil if none was set.
+func (opts *Options) GetCompactionFilterFactory() CompactionFilterFactory {
+	return opts.cff
+}
 
 // Version TWO of the compaction_filter_factory
 // It supports rolling compaction
@@ -166,6 +366,12 @@ func (opts *Options) SetCreateIfMissing(value bool) {
 	C.rocksdb_options_set_create_if_missing(opts.c, boolToChar(value))
 }
 
+// GetCreateIfMissing returns whether the database will be created
+// if it is missing.
+func (opts *Options) GetCreateIfMissing() bool {
+	return charToBool(C.rocksdb_options_get_create_if_missing(opts.c))
+}
+
 // SetErrorIfExists specifies whether an error should be raised
 // if the database already exists.
 // Default: false
@@ -173,6 +379,12 @@ func (opts *Options) SetErrorIfExists(value bool) {
 	C.rocksdb_options_set_error_if_exists(opts.c, boolToChar(value))
 }
 
+// GetErrorIfExists returns whether an error is raised if the database
+// already exists.
+func (opts *Options) GetErrorIfExists() bool {
+	return charToBool(C.rocksdb_options_get_error_if_exists(opts.c))
+}
+
 // SetParanoidChecks enable/disable paranoid checks.
 //
 // If true, the implementation will do aggressive checking of the
@@ -188,6 +400,11 @@ func (opts *Options) SetParanoidChecks(value bool) {
 	C.rocksdb_options_set_paranoid_checks(opts.c, boolToChar(value))
 }
 
+// GetParanoidChecks returns whether paranoid checks are enabled.
+func (opts *Options) GetParanoidChecks() bool {
+	return charToBool(C.rocksdb_options_get_paranoid_checks(opts.c))
+}
+
 // SetEnv sets the specified object to interact with the environment,
 // e.g. to read/write files, schedule background work, etc.
 // Default: DefaultEnv
@@ -197,12 +414,22 @@ func (opts *Options) SetEnv(value *Env) {
 	C.rocksdb_options_set_env(opts.c, value.c)
 }
 
+// GetEnv returns the environment, nil if the default environment is used.
+func (opts *Options) GetEnv() *Env {
+	return opts.env
+}
+
 // SetInfoLogLevel sets the info log level.
 // Default: InfoInfoLogLevel
 func (opts *Options) SetInfoLogLevel(value InfoLogLevel) {
 	C.rocksdb_options_set_info_log_level(opts.c, C.int(value))
 }
 
+// GetInfoLogLevel returns the info log level.
+func (opts *Options) GetInfoLogLevel() InfoLogLevel {
+	return InfoLogLevel(C.rocksdb_options_get_info_log_level(opts.c))
+}
+
 // IncreaseParallelism sets the parallelism.
 //
 // By default, RocksDB uses only one background thread for flush and
@@ -222,6 +449,7 @@ func (opts *Options) IncreaseParallelism(total_threads int) {
 // If you use this with rocksdb >= 5.0.2, you must call `SetAllowConcurrentMemtableWrites(false)`
 // to avoid an assertion error immediately on opening the db.
 func (opts *Options) OptimizeForPointLookup(block_cache_size_mb uint64) {
+	opts.bbto = nil
 	C.rocksdb_options_optimize_for_point_lookup(opts.c, C.uint64_t(block_cache_size_mb))
 }
 
@@ -233,6 +461,12 @@ func (opts *Options) SetAllowConcurrentMemtableWrites(allow bool) {
 	C.rocksdb_options_set_allow_concurrent_memtable_write(opts.c, boolToChar(allow))
 }
 
+// GetAllowConcurrentMemtableWrites returns whether concurrent memtable
+// writes are allowed.
+func (opts *Options) GetAllowConcurrentMemtableWrites() bool {
+	return charToBool(C.rocksdb_options_get_allow_concurrent_memtable_write(opts.c))
+}
+
 // OptimizeLevelStyleCompaction optimize the DB for leveld compaction.
 //
 // Default values for some parameters in ColumnFamilyOptions are not
@@ -256,6 +490,7 @@ func (opts *Options) OptimizeLevelStyleCompaction(memtable_memory_budget uint64)
 // OptimizeUniversalStyleCompaction optimize the DB for universal compaction.
 // See note on OptimizeLevelStyleCompaction.
 func (opts *Options) OptimizeUniversalStyleCompaction(memtable_memory_budget uint64) {
+	opts.uco = nil
 	C.rocksdb_options_optimize_universal_style_compaction(opts.c, C.uint64_t(memtable_memory_budget))
 }
 
@@ -273,6 +508,28 @@ func (opts *Options) SetWriteBufferSize(value int) {
 	C.rocksdb_options_set_write_buffer_size(opts.c, C.size_t(value))
 }
 
+// GetWriteBufferSize returns the amount of data to build up in memory
+// before converting to a sorted on-disk file.
+func (opts *Options) GetWriteBufferSize() int {
+	return int(C.rocksdb_options_get_write_buffer_size(opts.c))
+}
+
+// SetWriteBufferManager sets the manager which limits the memory used by
+// the memtables. A WriteBufferManager can be shared by the column families
+// and databases of a process, which then share its budget.
+// Default: nil, only write_buffer_size and max_write_buffer_number limit
+// the memtables
+func (opts *Options) SetWriteBufferManager(value *WriteBufferManager) {
+	opts.wbm = value
+	C.rocksdb_options_set_write_buffer_manager(opts.c, value.c)
+}
+
+// GetWriteBufferManager returns the write buffer manager, nil if none was
+// set.
+func (opts *Options) GetWriteBufferManager() *WriteBufferManager {
+	return opts.wbm
+}
+
 // SetMaxWriteBufferNumber sets the maximum number of write buffers
 // that are built up in memory.
 //
@@ -283,6 +540,12 @@ func (opts *Options) SetMaxWriteBufferNumber(value int) {
 	C.rocksdb_options_set_max_write_buffer_number(opts.c, C.int(value))
 }
 
+// GetMaxWriteBufferNumber returns the maximum number of write buffers
+// that are built up in memory.
+func (opts *Options) GetMaxWriteBufferNumber() int {
+	return int(C.rocksdb_options_get_max_write_buffer_number(opts.c))
+}
+
 // SetMinWriteBufferNumberToMerge sets the minimum number of write buffers
 // that will be merged together before writing to storage.
 //
@@ -296,6 +559,12 @@ func (opts *Options) SetMinWriteBufferNumberToMerge(value int) {
 	C.rocksdb_options_set_min_write_buffer_number_to_merge(opts.c, C.int(value))
 }
 
+// GetMinWriteBufferNumberToMerge returns the minimum number of write buffers
+// that will be merged together before writing to storage.
+func (opts *Options) GetMinWriteBufferNumberToMerge() int {
+	return int(C.rocksdb_options_get_min_write_buffer_number_to_merge(opts.c))
+}
+
 // SetMaxOpenFiles sets the number of open files that can be used by the DB.
 //
 // You may need to increase this if your database has a large working set
@@ -305,6 +574,11 @@ func (opts *Options) SetMaxOpenFiles(value int) {
 	C.rocksdb_options_set_max_open_files(opts.c, C.int(value))
 }
 
+// GetMaxOpenFiles returns the number of open files that can be used by the DB.
+func (opts *Options) GetMaxOpenFiles() int {
+	return int(C.rocksdb_options_get_max_open_files(opts.c))
+}
+
 // SetCompression sets the compression algorithm.
 // Default: SnappyCompression, which gives lightweight but fast
 // compression.
@@ -312,6 +586,11 @@ func (opts *Options) SetCompression(value CompressionType) {
 	C.rocksdb_options_set_compression(opts.c, C.int(value))
 }
 
+// GetCompression returns the compression algorithm.
+func (opts *Options) GetCompression() CompressionType {
+	return CompressionType(C.rocksdb_options_get_compression(opts.c))
+}
+
 // SetCompressionPerLevel sets different compression algorithm per level.
 //
 // Different levels can have different compression policies. There
@@ -338,7 +617,148 @@ func (opts *Options) SetMinLevelToCompress(value int) {
 // SetCompressionOptions sets different options for compression algorithms.
 // Default: nil
 func (opts *Options) SetCompressionOptions(value *CompressionOptions) {
+	copied := *value
+	opts.compressionOpts = &copied
 	C.rocksdb_options_set_compression_options(opts.c, C.int(value.WindowBits), C.int(value.Level), C.int(value.Strategy), C.int(value.MaxDictBytes))
+	C.rocksdb_options_set_compression_options_zstd_max_train_bytes(opts.c, C.int(value.ZstdMaxTrainBytes))
+}
+
+// GetCompressionOptions returns the options for compression algorithms.
+// ZstdMaxTrainBytes is read from RocksDB. The C API has no getters for the
+// other fields, they are the last values set through this wrapper.
+func (opts *Options) GetCompressionOptions() *CompressionOptions {
+	value := NewDefaultCompressionOptions()
+	if opts.compressionOpts != nil {
+		*value = *opts.compressionOpts
+	}
+	value.ZstdMaxTrainBytes = int(C.rocksdb_options_get_compression_options_zstd_max_train_bytes(opts.c))
+	return value
+}
+
+// SetBottommostCompression sets the compression algorithm of the
+// bottommost level, which holds most of the data. It overrides
+// SetCompression and SetCompressionPerLevel for that level.
+// Default: DisableCompressionOption, the bottommost level uses the
+// compression of the other levels
+func (opts *Options) SetBottommostCompression(value CompressionType) {
+	C.rocksdb_options_set_bottommost_compression(opts.c, C.int(value))
+}
+
+// GetBottommostCompression returns the compression algorithm of the
+// bottommost level.
+func (opts *Options) GetBottommostCompression() CompressionType {
+	return CompressionType(C.rocksdb_options_get_bottommost_compression(opts.c))
+}
+
+// SetBottommostCompressionOptions sets the options for the compression
+// algorithm of the bottommost level, e.g. a higher ZSTD level and a
+// dictionary. Once set, they are used instead of the options given to
+// SetCompressionOptions for that level.
+// Default: nil
+func (opts *Options) SetBottommostCompressionOptions(value *CompressionOptions) {
+	copied := *value
+	opts.bottommostCompressionOpts = &copied
+	C.rocksdb_options_set_bottommost_compression_options(opts.c, C.int(value.WindowBits), C.int(value.Level), C.int(value.Strategy), C.int(value.MaxDictBytes), boolToChar(true))
+	C.rocksdb_options_set_bottommost_compression_options_zstd_max_train_bytes(opts.c, C.int(value.ZstdMaxTrainBytes), boolToChar(true))
+}
+
+// GetBottommostCompressionOptions returns the options for the compression
+// algorithm of the bottommost level last set through this wrapper, nil if
+// none were set.
+func (opts *Options) GetBottommostCompressionOptions() *CompressionOptions {
+	if opts.bottommostCompressionOpts == nil {
+		return nil
+	}
+	value := *opts.bottommostCompressionOpts
+	return &value
+}
+
+// SetEnableBlobFiles specify whether values of at least min_blob_size bytes
+// are stored in separate blob files instead of the LSM tree. This reduces
+// the write amplification of large values, as compactions only move
+// references to them.
+// Default: false
+func (opts *Options) SetEnableBlobFiles(value bool) {
+	C.rocksdb_options_set_enable_blob_files(opts.c, boolToChar(value))
+}
+
+// GetEnableBlobFiles returns whether large values are stored in blob files.
+func (opts *Options) GetEnableBlobFiles() bool {
+	return charToBool(C.rocksdb_options_get_enable_blob_files(opts.c))
+}
+
+// SetMinBlobSize sets the size of the smallest value stored in a blob file.
+// Default: 0
+func (opts *Options) SetMinBlobSize(value uint64) {
+	C.rocksdb_options_set_min_blob_size(opts.c, C.uint64_t(value))
+}
+
+// GetMinBlobSize returns the size of the smallest value stored in a blob
+// file.
+func (opts *Options) GetMinBlobSize() uint64 {
+	return uint64(C.rocksdb_options_get_min_blob_size(opts.c))
+}
+
+// SetBlobFileSize sets the size at which a blob file is closed and a new
+// one started.
+// Default: 256MB
+func (opts *Options) SetBlobFileSize(value uint64) {
+	C.rocksdb_options_set_blob_file_size(opts.c, C.uint64_t(value))
+}
+
+// GetBlobFileSize returns the size at which a blob file is closed.
+func (opts *Options) GetBlobFileSize() uint64 {
+	return uint64(C.rocksdb_options_get_blob_file_size(opts.c))
+}
+
+// SetBlobCompressionType sets the compression algorithm of blob files.
+// Default: NoCompression
+func (opts *Options) SetBlobCompressionType(value CompressionType) {
+	C.rocksdb_options_set_blob_compression_type(opts.c, C.int(value))
+}
+
+// GetBlobCompressionType returns the compression algorithm of blob files.
+func (opts *Options) GetBlobCompressionType() CompressionType {
+	return CompressionType(C.rocksdb_options_get_blob_compression_type(opts.c))
+}
+
+// SetEnableBlobGC specify whether compactions rewrite the valid values of
+// old blob files, so that these files can be deleted.
+// Default: false
+func (opts *Options) SetEnableBlobGC(value bool) {
+	C.rocksdb_options_set_enable_blob_gc(opts.c, boolToChar(value))
+}
+
+// GetEnableBlobGC returns whether old blob files are garbage collected.
+func (opts *Options) GetEnableBlobGC() bool {
+	return charToBool(C.rocksdb_options_get_enable_blob_gc(opts.c))
+}
+
+// SetBlobGCAgeCutoff sets the fraction of the oldest blob files which are
+// garbage collected, between 0 and 1.
+// Default: 0.25
+func (opts *Options) SetBlobGCAgeCutoff(value float64) {
+	C.rocksdb_options_set_blob_gc_age_cutoff(opts.c, C.double(value))
+}
+
+// GetBlobGCAgeCutoff returns the fraction of the oldest blob files which
+// are garbage collected.
+func (opts *Options) GetBlobGCAgeCutoff() float64 {
+	return float64(C.rocksdb_options_get_blob_gc_age_cutoff(opts.c))
+}
+
+// SetBlobGCForceThreshold sets the ratio of garbage in the oldest blob
+// files at which a compaction of the files referencing them is forced,
+// between 0 and 1. A value of 1 disables forced compactions.
+// Default: 1.0
+func (opts *Options) SetBlobGCForceThreshold(value float64) {
+	C.rocksdb_options_set_blob_gc_force_threshold(opts.c, C.double(value))
+}
+
+// GetBlobGCForceThreshold returns the ratio of garbage at which a
+// compaction is forced.
+func (opts *Options) GetBlobGCForceThreshold() float64 {
+	return float64(C.rocksdb_options_get_blob_gc_force_threshold(opts.c))
 }
 
 // SetPrefixExtractor sets the prefic extractor.
@@ -350,13 +770,20 @@ func (opts *Options) SetCompressionOptions(value *CompressionOptions) {
 // db.NewIterator().
 // Default: nil
 func (opts *Options) SetPrefixExtractor(value SliceTransform) {
+	opts.st = value
+	var cst *C.rocksdb_slicetransform_t
 	if nst, ok := value.(nativeSliceTransform); ok {
-		opts.cst = nst.c
+		cst = nst.c
 	} else {
 		idx := registerSliceTransform(value)
-		opts.cst = C.gorocksdb_slicetransform_create(C.uintptr_t(idx))
+		cst = C.gorocksdb_slicetransform_create(C.uintptr_t(idx))
 	}
-	C.rocksdb_options_set_prefix_extractor(opts.c, opts.cst)
+	C.rocksdb_options_set_prefix_extractor(opts.c, cst)
+}
+
+// GetPrefixExtractor returns the prefix extractor, nil if none was set.
+func (opts *Options) GetPrefixExtractor() SliceTransform {
+	return opts.st
 }
 
 // SetNumLevels sets the number of levels for this database.
@@ -365,6 +792,11 @@ func (opts *Options) SetNumLevels(value int) {
 	C.rocksdb_options_set_num_levels(opts.c, C.int(value))
 }
 
+// GetNumLevels returns the number of levels for this database.
+func (opts *Options) GetNumLevels() int {
+	return int(C.rocksdb_options_get_num_levels(opts.c))
+}
+
 // SetLevel0FileNumCompactionTrigger sets the number of files
 // to trigger level-0 compaction.
 //
@@ -375,6 +807,12 @@ func (opts *Options) SetLevel0FileNumCompactionTrigger(value int) {
 	C.rocksdb_options_set_level0_file_num_compaction_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0FileNumCompactionTrigger returns the number of files
+// to trigger level-0 compaction.
+func (opts *Options) GetLevel0FileNumCompactionTrigger() int {
+	return int(C.rocksdb_options_get_level0_file_num_compaction_trigger(opts.c))
+}
+
 // SetLevel0SlowdownWritesTrigger sets the soft limit on number of level-0 files.
 //
 // We start slowing down writes at this point.
@@ -385,6 +823,11 @@ func (opts *Options) SetLevel0SlowdownWritesTrigger(value int) {
 	C.rocksdb_options_set_level0_slowdown_writes_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0SlowdownWritesTrigger returns the soft limit on number of level-0 files.
+func (opts *Options) GetLevel0SlowdownWritesTrigger() int {
+	return int(C.rocksdb_options_get_level0_slowdown_writes_trigger(opts.c))
+}
+
 // SetLevel0StopWritesTrigger sets the maximum number of level-0 files.
 // We stop writes at this point.
 // Default: 12
@@ -392,6 +835,11 @@ func (opts *Options) SetLevel0StopWritesTrigger(value int) {
 	C.rocksdb_options_set_level0_stop_writes_trigger(opts.c, C.int(value))
 }
 
+// GetLevel0StopWritesTrigger returns the maximum number of level-0 files.
+func (opts *Options) GetLevel0StopWritesTrigger() int {
+	return int(C.rocksdb_options_get_level0_stop_writes_trigger(opts.c))
+}
+
 // SetMaxMemCompactionLevel sets the maximum level
 // to which a new compacted memtable is pushed if it does not create overlap.
 //
@@ -420,12 +868,22 @@ func (opts *Options) SetTargetFileSizeBase(value uint64) {
 	C.rocksdb_options_set_target_file_size_base(opts.c, C.uint64_t(value))
 }
 
+// GetTargetFileSizeBase returns the target file size for compaction.
+func (opts *Options) GetTargetFileSizeBase() uint64 {
+	return uint64(C.rocksdb_options_get_target_file_size_base(opts.c))
+}
+
 // SetTargetFileSizeMultiplier sets the target file size multiplier for compaction.
 // Default: 1
 func (opts *Options) SetTargetFileSizeMultiplier(value int) {
 	C.rocksdb_options_set_target_file_size_multiplier(opts.c, C.int(value))
 }
 
+// GetTargetFileSizeMultiplier returns the target file size multiplier for compaction.
+func (opts *Options) GetTargetFileSizeMultiplier() int {
+	return int(C.rocksdb_options_get_target_file_size_multiplier(opts.c))
+}
+
 // SetMaxBytesForLevelBase sets the maximum total data size for a level.
 //
 // It is the max total for level-1.
@@ -441,12 +899,22 @@ func (opts *Options) SetMaxBytesForLevelBase(value uint64) {
 	C.rocksdb_options_set_max_bytes_for_level_base(opts.c, C.uint64_t(value))
 }
 
+// GetMaxBytesForLevelBase returns the maximum total data size for level-1.
+func (opts *Options) GetMaxBytesForLevelBase() uint64 {
+	return uint64(C.rocksdb_options_get_max_bytes_for_level_base(opts.c))
+}
+
 // SetMaxBytesForLevelMultiplier sets the max Bytes for level multiplier.
 // Default: 10
 func (opts *Options) SetMaxBytesForLevelMultiplier(value float64) {
 	C.rocksdb_options_set_max_bytes_for_level_multiplier(opts.c, C.double(value))
 }
 
+// GetMaxBytesForLevelMultiplier returns the max Bytes for level multiplier.
+func (opts *Options) GetMaxBytesForLevelMultiplier() float64 {
+	return float64(C.rocksdb_options_get_max_bytes_for_level_multiplier(opts.c))
+}
+
 // SetMaxBytesForLevelMultiplierAdditional sets different max-size multipliers
 // for different levels.
 //
@@ -454,6 +922,7 @@ func (opts *Options) SetMaxBytesForLevelMultiplier(value float64) {
 // at the max-size of each level.
 // Default: 1 for each level
 func (opts *Options) SetMaxBytesForLevelMultiplierAdditional(value []int) {
+	opts.maxBytesForLevelMultiplierAdditional = append([]int(nil), value...)
 	cLevels := make([]C.int, len(value))
 	for i, v := range value {
 		cLevels[i] = C.int(v)
@@ -462,6 +931,12 @@ func (opts *Options) SetMaxBytesForLevelMultiplierAdditional(value []int) {
 	C.rocksdb_options_set_max_bytes_for_level_multiplier_additional(opts.c, &cLevels[0], C.size_t(len(value)))
 }
 
+// GetMaxBytesForLevelMultiplierAdditional returns the max-size multipliers
+// per level last set through this wrapper.
+func (opts *Options) GetMaxBytesForLevelMultiplierAdditional() []int {
+	return append([]int(nil), opts.maxBytesForLevelMultiplierAdditional...)
+}
+
 // SetUseFsync enable/disable fsync.
 //
 // If true, then every store to stable storage will issue a fsync.
@@ -473,6 +948,11 @@ func (opts *Options) SetUseFsync(value bool) {
 	C.rocksdb_options_set_use_fsync(opts.c, C.int(btoi(value)))
 }
 
+// GetUseFsync returns whether fsync is used instead of fdatasync.
+func (opts *Options) GetUseFsync() bool {
+	return C.rocksdb_options_get_use_fsync(opts.c) != 0
+}
+
 // SetDbLogDir specifies the absolute info LOG dir.
 //
 // If it is empty, the log files will be in the same dir as data.
@@ -481,11 +961,17 @@ func (opts *Options) SetUseFsync(value bool) {
 // name's prefix.
 // Default: empty
 func (opts *Options) SetDbLogDir(value string) {
+	opts.dbLogDir = value
 	cvalue := C.CString(value)
 	defer C.free(unsafe.Pointer(cvalue))
 	C.rocksdb_options_set_db_log_dir(opts.c, cvalue)
 }
 
+// GetDbLogDir returns the info log dir last set through this wrapper.
+func (opts *Options) GetDbLogDir() string {
+	return opts.dbLogDir
+}
+
 // SetWalDir specifies the absolute dir path for write-ahead logs (WAL).
 //
 // If it is empty, the log files will be in the same dir as data.
@@ -493,11 +979,18 @@ func (opts *Options) SetDbLogDir(value string) {
 // When destroying the db, all log files and the dir itopts is deleted.
 // Default: empty
 func (opts *Options) SetWalDir(value string) {
+	opts.walDir = value
 	cvalue := C.CString(value)
 	defer C.free(unsafe.Pointer(cvalue))
 	C.rocksdb_options_set_wal_dir(opts.c, cvalue)
 }
 
+// GetWalDir returns the dir for write-ahead logs last set through this
+// wrapper.
+func (opts *Options) GetWalDir() string {
+	return opts.walDir
+}
+
 // SetDeleteObsoleteFilesPeriodMicros sets the periodicity
 // when obsolete files get deleted.
 //
@@ -509,6 +1002,12 @@ func (opts *Options) SetDeleteObsoleteFilesPeriodMicros(value uint64) {
 	C.rocksdb_options_set_delete_obsolete_files_period_micros(opts.c, C.uint64_t(value))
 }
 
+// GetDeleteObsoleteFilesPeriodMicros returns the periodicity
+// when obsolete files get deleted.
+func (opts *Options) GetDeleteObsoleteFilesPeriodMicros() uint64 {
+	return uint64(C.rocksdb_options_get_delete_obsolete_files_period_micros(opts.c))
+}
+
 // SetMaxBackgroundCompactions sets the maximum number of
 // concurrent background jobs, submitted to
 // the default LOW priority thread pool
@@ -517,6 +1016,12 @@ func (opts *Options) SetMaxBackgroundCompactions(value int) {
 	C.rocksdb_options_set_max_background_compactions(opts.c, C.int(value))
 }
 
+// GetMaxBackgroundCompactions returns the maximum number of
+// concurrent background compaction jobs.
+func (opts *Options) GetMaxBackgroundCompactions() int {
+	return int(C.rocksdb_options_get_max_background_compactions(opts.c))
+}
+
 // SetMaxBackgroundFlushes sets the maximum number of
 // concurrent background memtable flush jobs, submitted to
 // the HIGH priority thread pool.
@@ -533,6 +1038,12 @@ func (opts *Options) SetMaxBackgroundFlushes(value int) {
 	C.rocksdb_options_set_max_background_flushes(opts.c, C.int(value))
 }
 
+// GetMaxBackgroundFlushes returns the maximum number of
+// concurrent background memtable flush jobs.
+func (opts *Options) GetMaxBackgroundFlushes() int {
+	return int(C.rocksdb_options_get_max_background_flushes(opts.c))
+}
+
 // SetMaxLogFileSize sets the maximal size of the info log file.
 //
 // If the log file is larger than `max_log_file_size`, a new info log
@@ -543,6 +1054,11 @@ func (opts *Options) SetMaxLogFileSize(value int) {
 	C.rocksdb_options_set_max_log_file_size(opts.c, C.size_t(value))
 }
 
+// GetMaxLogFileSize returns the maximal size of the info log file.
+func (opts *Options) GetMaxLogFileSize() int {
+	return int(C.rocksdb_options_get_max_log_file_size(opts.c))
+}
+
 // SetLogFileTimeToRoll sets the time for the info log file to roll (in seconds).
 //
 // If specified with non-zero value, log file will be rolled
@@ -552,12 +1068,22 @@ func (opts *Options) SetLogFileTimeToRoll(value int) {
 	C.rocksdb_options_set_log_file_time_to_roll(opts.c, C.size_t(value))
 }
 
+// GetLogFileTimeToRoll returns the time for the info log file to roll (in seconds).
+func (opts *Options) GetLogFileTimeToRoll() int {
+	return int(C.rocksdb_options_get_log_file_time_to_roll(opts.c))
+}
+
 // SetKeepLogFileNum sets the maximal info log files to be kept.
 // Default: 1000
 func (opts *Options) SetKeepLogFileNum(value int) {
 	C.rocksdb_options_set_keep_log_file_num(opts.c, C.size_t(value))
 }
 
+// GetKeepLogFileNum returns the maximal info log files to be kept.
+func (opts *Options) GetKeepLogFileNum() int {
+	return int(C.rocksdb_options_get_keep_log_file_num(opts.c))
+}
+
 // SetSoftRateLimit sets the soft rate limit.
 //
 // Puts are delayed 0-1 ms when any level has a compaction score that exceeds
@@ -565,6 +1091,8 @@ func (opts *Options) SetKeepLogFileNum(value int) {
 // CONSTRAINT: soft_rate_limit <= hard_rate_limit. If this constraint does not
 // hold, RocksDB will set soft_rate_limit = hard_rate_limit
 // Default: 0.0 (disabled)
+//
+// It does not limit the I/O of the database, see SetRateLimiter.
 func (opts *Options) SetSoftRateLimit(value float64) {
 	C.rocksdb_options_set_soft_rate_limit(opts.c, C.double(value))
 }
@@ -586,6 +1114,19 @@ func (opts *Options) SetRateLimitDelayMaxMilliseconds(value uint) {
 	C.rocksdb_options_set_rate_limit_delay_max_milliseconds(opts.c, C.uint(value))
 }
 
+// SetRateLimiter sets the rate limiter of the background I/O of flushes
+// and compactions. A RateLimiter can be shared by several databases.
+// Default: nil, the I/O is not limited
+func (opts *Options) SetRateLimiter(value *RateLimiter) {
+	opts.rl = value
+	C.rocksdb_options_set_ratelimiter(opts.c, value.c)
+}
+
+// GetRateLimiter returns the rate limiter, nil if none was set.
+func (opts *Options) GetRateLimiter() *RateLimiter {
+	return opts.rl
+}
+
 // SetMaxManifestFileSize sets the maximal manifest file size until is rolled over.
 // The older manifest file be deleted.
 // Default: MAX_INT so that roll-over does not take place.
@@ -593,12 +1134,22 @@ func (opts *Options) SetMaxManifestFileSize(value uint64) {
 	C.rocksdb_options_set_max_manifest_file_size(opts.c, C.size_t(value))
 }
 
+// GetMaxManifestFileSize returns the maximal manifest file size until is rolled over.
+func (opts *Options) GetMaxManifestFileSize() uint64 {
+	return uint64(C.rocksdb_options_get_max_manifest_file_size(opts.c))
+}
+
 // SetTableCacheNumshardbits sets the number of shards used for table cache.
 // Default: 4
 func (opts *Options) SetTableCacheNumshardbits(value int) {
 	C.rocksdb_options_set_table_cache_numshardbits(opts.c, C.int(value))
 }
 
+// GetTableCacheNumshardbits returns the number of shards used for table cache.
+func (opts *Options) GetTableCacheNumshardbits() int {
+	return int(C.rocksdb_options_get_table_cache_numshardbits(opts.c))
+}
+
 // SetTableCacheRemoveScanCountLimit sets the count limit during a scan.
 //
 // During data eviction of table's LRU cache, it would be inefficient
@@ -621,6 +1172,11 @@ func (opts *Options) SetArenaBlockSize(value int) {
 	C.rocksdb_options_set_arena_block_size(opts.c, C.size_t(value))
 }
 
+// GetArenaBlockSize returns the size of one block in arena memory allocation.
+func (opts *Options) GetArenaBlockSize() int {
+	return int(C.rocksdb_options_get_arena_block_size(opts.c))
+}
+
 // SetDisableAutoCompactions enable/disable automatic compactions.
 //
 // Manual compactions can still be issued on this database.
@@ -629,6 +1185,11 @@ func (opts *Options) SetDisableAutoCompactions(value bool) {
 	C.rocksdb_options_set_disable_auto_compactions(opts.c, C.int(btoi(value)))
 }
 
+// GetDisableAutoCompactions returns whether automatic compactions are disabled.
+func (opts *Options) GetDisableAutoCompactions() bool {
+	return charToBool(C.rocksdb_options_get_disable_auto_compactions(opts.c))
+}
+
 // SetWALTtlSeconds sets the WAL ttl in seconds.
 //
 // The following two options affect how archived logs will be deleted.
@@ -648,6 +1209,11 @@ func (opts *Options) SetWALTtlSeconds(value uint64) {
 	C.rocksdb_options_set_WAL_ttl_seconds(opts.c, C.uint64_t(value))
 }
 
+// GetWALTtlSeconds returns the WAL ttl in seconds.
+func (opts *Options) GetWALTtlSeconds() uint64 {
+	return uint64(C.rocksdb_options_get_WAL_ttl_seconds(opts.c))
+}
+
 // SetWalSizeLimitMb sets the WAL size limit in MB.
 //
 // If total size of WAL files is greater then wal_size_limit_mb,
@@ -657,6 +1223,24 @@ func (opts *Options) SetWalSizeLimitMb(value uint64) {
 	C.rocksdb_options_set_WAL_size_limit_MB(opts.c, C.uint64_t(value))
 }
 
+// GetWalSizeLimitMb returns the WAL size limit in MB.
+func (opts *Options) GetWalSizeLimitMb() uint64 {
+	return uint64(C.rocksdb_options_get_WAL_size_limit_MB(opts.c))
+}
+
+// SetManualWALFlush sets whether WAL writes are buffered in memory until
+// DB.FlushWAL is called, instead of being written out after every write.
+// This trades durability on process crash for fewer write syscalls.
+// Default: false
+func (opts *Options) SetManualWALFlush(value bool) {
+	C.rocksdb_options_set_manual_wal_flush(opts.c, boolToChar(value))
+}
+
+// GetManualWALFlush returns whether WAL writes are flushed manually.
+func (opts *Options) GetManualWALFlush() bool {
+	return charToBool(C.rocksdb_options_get_manual_wal_flush(opts.c))
+}
+
 // SetManifestPreallocationSize sets the number of bytes
 // to preallocate (via fallocate) the manifest files.
 //
@@ -668,6 +1252,12 @@ func (opts *Options) SetManifestPreallocationSize(value int) {
 	C.rocksdb_options_set_manifest_preallocation_size(opts.c, C.size_t(value))
 }
 
+// GetManifestPreallocationSize returns the number of bytes
+// to preallocate (via fallocate) the manifest files.
+func (opts *Options) GetManifestPreallocationSize() int {
+	return int(C.rocksdb_options_get_manifest_preallocation_size(opts.c))
+}
+
 // SetPurgeRedundantKvsWhileFlush enable/disable purging of
 // duplicate/deleted keys when a memtable is flushed to storage.
 // Default: true
@@ -681,22 +1271,48 @@ func (opts *Options) SetAllowMmapReads(value bool) {
 	C.rocksdb_options_set_allow_mmap_reads(opts.c, boolToChar(value))
 }
 
+// GetAllowMmapReads returns whether mmap reads are allowed.
+func (opts *Options) GetAllowMmapReads() bool {
+	return charToBool(C.rocksdb_options_get_allow_mmap_reads(opts.c))
+}
+
 // SetAllowMmapWrites enable/disable mmap writes for writing sst tables.
 // Default: true
 func (opts *Options) SetAllowMmapWrites(value bool) {
 	C.rocksdb_options_set_allow_mmap_writes(opts.c, boolToChar(value))
 }
 
+// GetAllowMmapWrites returns whether mmap writes are allowed.
+func (opts *Options) GetAllowMmapWrites() bool {
+	return charToBool(C.rocksdb_options_get_allow_mmap_writes(opts.c))
+}
+
 // SetUseDirectReads enable/disable direct I/O mode (O_DIRECT) for reads
 // Default: false
 func (opts *Options) SetUseDirectReads(value bool) {
 	C.rocksdb_options_set_use_direct_reads(opts.c, boolToChar(value))
 }
 
+// GetUseDirectReads returns whether direct I/O mode is used for reads.
+func (opts *Options) GetUseDirectReads() bool {
+	return charToBool(C.rocksdb_options_get_use_direct_reads(opts.c))
+}
+
 // SetUseDirectWrites enable/disable direct I/O mode (O_DIRECT) for writes
+// of flushes and compactions.
+//
+// This sets use_direct_io_for_flush_and_compaction. Earlier versions set
+// the deprecated use_direct_writes option instead, which RocksDB maps to
+// the same behaviour.
 // Default: false
 func (opts *Options) SetUseDirectWrites(value bool) {
-	C.rocksdb_options_set_use_direct_writes(opts.c, boolToChar(value))
+	C.rocksdb_options_set_use_direct_io_for_flush_and_compaction(opts.c, boolToChar(value))
+}
+
+// GetUseDirectWrites returns whether direct I/O mode is used for writes
+// of flushes and compactions.
+func (opts *Options) GetUseDirectWrites() bool {
+	return charToBool(C.rocksdb_options_get_use_direct_io_for_flush_and_compaction(opts.c))
 }
 
 // SetIsFdCloseOnExec enable/dsiable child process inherit open files.
@@ -705,6 +1321,11 @@ func (opts *Options) SetIsFdCloseOnExec(value bool) {
 	C.rocksdb_options_set_is_fd_close_on_exec(opts.c, boolToChar(value))
 }
 
+// GetIsFdCloseOnExec returns whether child processes don't inherit open files.
+func (opts *Options) GetIsFdCloseOnExec() bool {
+	return charToBool(C.rocksdb_options_get_is_fd_close_on_exec(opts.c))
+}
+
 // SetSkipLogErrorOnRecovery enable/disable skipping of
 // log corruption error on recovery (If client is ok with
 // losing most recent changes)
@@ -721,6 +1342,11 @@ func (opts *Options) SetStatsDumpPeriodSec(value uint) {
 	C.rocksdb_options_set_stats_dump_period_sec(opts.c, C.uint(value))
 }
 
+// GetStatsDumpPeriodSec returns the stats dump period in seconds.
+func (opts *Options) GetStatsDumpPeriodSec() uint {
+	return uint(C.rocksdb_options_get_stats_dump_period_sec(opts.c))
+}
+
 // SetAdviseRandomOnOpen specifies whether we will hint the underlying
 // file system that the file access pattern is random, when a sst file is opened.
 // Default: true
@@ -728,6 +1354,12 @@ func (opts *Options) SetAdviseRandomOnOpen(value bool) {
 	C.rocksdb_options_set_advise_random_on_open(opts.c, boolToChar(value))
 }
 
+// GetAdviseRandomOnOpen returns whether a random access pattern is hinted
+// when a sst file is opened.
+func (opts *Options) GetAdviseRandomOnOpen() bool {
+	return charToBool(C.rocksdb_options_get_advise_random_on_open(opts.c))
+}
+
 // SetAccessHintOnCompactionStart specifies the file access pattern
 // once a compaction is started.
 //
@@ -737,6 +1369,12 @@ func (opts *Options) SetAccessHintOnCompactionStart(value CompactionAccessPatter
 	C.rocksdb_options_set_access_hint_on_compaction_start(opts.c, C.int(value))
 }
 
+// GetAccessHintOnCompactionStart returns the file access pattern
+// once a compaction is started.
+func (opts *Options) GetAccessHintOnCompactionStart() CompactionAccessPattern {
+	return CompactionAccessPattern(C.rocksdb_options_get_access_hint_on_compaction_start(opts.c))
+}
+
 // SetUseAdaptiveMutex enable/disable adaptive mutex, which spins
 // in the user space before resorting to kernel.
 //
@@ -748,6 +1386,11 @@ func (opts *Options) SetUseAdaptiveMutex(value bool) {
 	C.rocksdb_options_set_use_adaptive_mutex(opts.c, boolToChar(value))
 }
 
+// GetUseAdaptiveMutex returns whether the adaptive mutex is used.
+func (opts *Options) GetUseAdaptiveMutex() bool {
+	return charToBool(C.rocksdb_options_get_use_adaptive_mutex(opts.c))
+}
+
 // SetBytesPerSync sets the bytes per sync.
 //
 // Allows OS to incrementally sync files to disk while they are being
@@ -758,25 +1401,50 @@ func (opts *Options) SetBytesPerSync(value uint64) {
 	C.rocksdb_options_set_bytes_per_sync(opts.c, C.uint64_t(value))
 }
 
+// GetBytesPerSync returns the bytes per sync.
+func (opts *Options) GetBytesPerSync() uint64 {
+	return uint64(C.rocksdb_options_get_bytes_per_sync(opts.c))
+}
+
 // SetCompactionStyle sets the compaction style.
 // Default: LevelCompactionStyle
 func (opts *Options) SetCompactionStyle(value CompactionStyle) {
 	C.rocksdb_options_set_compaction_style(opts.c, C.int(value))
 }
 
+// GetCompactionStyle returns the compaction style.
+func (opts *Options) GetCompactionStyle() CompactionStyle {
+	return CompactionStyle(C.rocksdb_options_get_compaction_style(opts.c))
+}
+
 // SetUniversalCompactionOptions sets the options needed
 // to support Universal Style compactions.
 // Default: nil
 func (opts *Options) SetUniversalCompactionOptions(value *UniversalCompactionOptions) {
+	opts.uco = value
 	C.rocksdb_options_set_universal_compaction_options(opts.c, value.c)
 }
 
+// GetUniversalCompactionOptions returns the options for universal
+// compaction style last set through this wrapper, nil if none were set or
+// OptimizeUniversalStyleCompaction replaced them.
+func (opts *Options) GetUniversalCompactionOptions() *UniversalCompactionOptions {
+	return opts.uco
+}
+
 // SetFIFOCompactionOptions sets the options for FIFO compaction style.
 // Default: nil
 func (opts *Options) SetFIFOCompactionOptions(value *FIFOCompactionOptions) {
+	opts.fco = value
 	C.rocksdb_options_set_fifo_compaction_options(opts.c, value.c)
 }
 
+// GetFIFOCompactionOptions returns the options for FIFO compaction style
+// last set through this wrapper, nil if none were set.
+func (opts *Options) GetFIFOCompactionOptions() *FIFOCompactionOptions {
+	return opts.fco
+}
+
 // SetMaxSequentialSkipInIterations specifies whether an iteration->Next()
 // sequentially skips over keys with the same user-key or not.
 //
@@ -787,6 +1455,12 @@ func (opts *Options) SetMaxSequentialSkipInIterations(value uint64) {
 	C.rocksdb_options_set_max_sequential_skip_in_iterations(opts.c, C.uint64_t(value))
 }
 
+// GetMaxSequentialSkipInIterations returns the number of keys (with the same
+// userkey) that will be sequentially skipped before a reseek is issued.
+func (opts *Options) GetMaxSequentialSkipInIterations() uint64 {
+	return uint64(C.rocksdb_options_get_max_sequential_skip_in_iterations(opts.c))
+}
+
 // SetInplaceUpdateSupport enable/disable thread-safe inplace updates.
 //
 // Requires updates if
@@ -798,12 +1472,22 @@ func (opts *Options) SetInplaceUpdateSupport(value bool) {
 	C.rocksdb_options_set_inplace_update_support(opts.c, boolToChar(value))
 }
 
+// GetInplaceUpdateSupport returns whether thread-safe inplace updates are enabled.
+func (opts *Options) GetInplaceUpdateSupport() bool {
+	return charToBool(C.rocksdb_options_get_inplace_update_support(opts.c))
+}
+
 // SetInplaceUpdateNumLocks sets the number of locks used for inplace update.
 // Default: 10000, if inplace_update_support = true, else 0.
 func (opts *Options) SetInplaceUpdateNumLocks(value int) {
 	C.rocksdb_options_set_inplace_update_num_locks(opts.c, C.size_t(value))
 }
 
+// GetInplaceUpdateNumLocks returns the number of locks used for inplace update.
+func (opts *Options) GetInplaceUpdateNumLocks() int {
+	return int(C.rocksdb_options_get_inplace_update_num_locks(opts.c))
+}
+
 // SetBloomLocality sets the bloom locality.
 //
 // Control locality of bloom filter probes to improve cache miss rate.
@@ -819,6 +1503,11 @@ func (opts *Options) SetBloomLocality(value uint32) {
 	C.rocksdb_options_set_bloom_locality(opts.c, C.uint32_t(value))
 }
 
+// GetBloomLocality returns the bloom locality.
+func (opts *Options) GetBloomLocality() uint32 {
+	return uint32(C.rocksdb_options_get_bloom_locality(opts.c))
+}
+
 // SetMaxSuccessiveMerges sets the maximum number of
 // successive merge operations on a key in the memtable.
 //
@@ -832,6 +1521,12 @@ func (opts *Options) SetMaxSuccessiveMerges(value int) {
 	C.rocksdb_options_set_max_successive_merges(opts.c, C.size_t(value))
 }
 
+// GetMaxSuccessiveMerges returns the maximum number of
+// successive merge operations on a key in the memtable.
+func (opts *Options) GetMaxSuccessiveMerges() int {
+	return int(C.rocksdb_options_get_max_successive_merges(opts.c))
+}
+
 // EnableStatistics enable statistics.
 func (opts *Options) EnableStatistics() {
 	C.rocksdb_options_enable_statistics(opts.c)
@@ -903,28 +1598,69 @@ func (opts *Options) SetCreateIfMissingColumnFamilies(value bool) {
 	C.rocksdb_options_set_create_missing_column_families(opts.c, boolToChar(value))
 }
 
+// GetCreateIfMissingColumnFamilies returns whether the column families
+// will be created if they are missing.
+func (opts *Options) GetCreateIfMissingColumnFamilies() bool {
+	return charToBool(C.rocksdb_options_get_create_missing_column_families(opts.c))
+}
+
 // SetBlockBasedTableFactory sets the block based table factory.
 func (opts *Options) SetBlockBasedTableFactory(value *BlockBasedTableOptions) {
 	opts.bbto = value
 	C.rocksdb_options_set_block_based_table_factory(opts.c, value.c)
 }
 
+// GetBlockBasedTableFactory returns the options of the block based table
+// factory last set through this wrapper, nil if none were set or
+// OptimizeForPointLookup replaced the table factory.
+func (opts *Options) GetBlockBasedTableFactory() *BlockBasedTableOptions {
+	return opts.bbto
+}
+
+// SetBlockBasedTableFactoryFromString sets the block based table factory
+// from a RocksDB option string like "block_size=16K;filter_policy=bloomfilter:10:false".
+// Table options not present in the string are taken from the current block
+// based table factory, if any.
+//
+// The table options can't be read back, so GetBlockBasedTableFactory
+// returns nil afterwards.
+func (opts *Options) SetBlockBasedTableFactoryFromString(optsStr string) error {
+	cOptsStr := C.CString("block_based_table_factory={" + optsStr + "}")
+	defer C.free(unsafe.Pointer(cOptsStr))
+
+	var cErr *C.char
+	cOpts := C.rocksdb_options_create()
+	C.rocksdb_get_options_from_string(opts.c, cOptsStr, cOpts, &cErr)
+	if cErr != nil {
+		defer C.free(unsafe.Pointer(cErr))
+		C.rocksdb_options_destroy(cOpts)
+		return newOptionError(C.GoString(cErr))
+	}
+	C.rocksdb_options_destroy(opts.c)
+	opts.c = cOpts
+	opts.bbto = nil
+	return nil
+}
+
 // Destroy deallocates the Options object.
 func (opts *Options) Destroy() {
 	C.rocksdb_options_destroy(opts.c)
-	if opts.ccmp != nil {
-		C.rocksdb_comparator_destroy(opts.ccmp)
-	}
-	if opts.cmo != nil {
-		C.rocksdb_mergeoperator_destroy(opts.cmo)
-	}
-	if opts.cst != nil {
-		C.rocksdb_slicetransform_destroy(opts.cst)
-	}
-	if opts.ccf != nil {
-		C.rocksdb_compactionfilter_destroy(opts.ccf)
-	}
+	opts.ccmp.release()
+	opts.ccf.release()
+	opts.cbuilt.release()
 	opts.c = nil
+	opts.ccmp = nil
+	opts.ccf = nil
+	opts.cbuilt = nil
 	opts.env = nil
 	opts.bbto = nil
+	opts.uco = nil
+	opts.fco = nil
+	opts.wbm = nil
+	opts.rl = nil
+	opts.cmp = nil
+	opts.mo = nil
+	opts.cf = nil
+	opts.cff = nil
+	opts.st = nil
 }
@@ -4,7 +4,41 @@ package gorocksdb
 // #include "gorocksdb.h"
 import "C"
 
+// IndexType specifies the index type that will be used for a table.
+type IndexType uint
+
+// Index types.
+const (
+	// KBinarySearchIndexType is a space efficient index block that is
+	// optimized for binary search-based index.
+	KBinarySearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_binary_search)
+	// KHashSearchIndexType is a hash index which is built on top of the
+	// binary search index. It requires a prefix extractor.
+	KHashSearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_hash_search)
+	// KTwoLevelIndexSearchIndexType is a two-level index which partitions
+	// the index into blocks, see SetPartitionFilters.
+	KTwoLevelIndexSearchIndexType = IndexType(C.rocksdb_block_based_table_index_type_two_level_index_search)
+)
+
+// DataBlockIndexType specifies the index type of data blocks.
+type DataBlockIndexType uint
+
+// Data block index types.
+const (
+	// KDataBlockIndexTypeBinarySearch searches a data block by binary search.
+	KDataBlockIndexTypeBinarySearch = DataBlockIndexType(C.rocksdb_block_based_table_data_block_index_type_binary_search)
+	// KDataBlockIndexTypeBinarySearchAndHash appends a hash index to each
+	// data block, which speeds up point lookups.
+	KDataBlockIndexTypeBinarySearchAndHash = DataBlockIndexType(C.rocksdb_block_based_table_data_block_index_type_binary_search_and_hash)
+)
+
 // BlockBasedTableOptions represents block-based table options.
+//
+// The C API has no getters for these options, so the getters return the
+// last value set through Go, starting from RocksDB's defaults. They are
+// wrong for an object wrapped with NewNativeBlockBasedTableOptions that was
+// configured in C, and they don't describe tables configured from an
+// option string, e.g. with Options.SetBlockBasedTableFactoryFromString.
 type BlockBasedTableOptions struct {
 	c *C.rocksdb_block_based_table_options_t
 
@@ -14,6 +48,27 @@ type BlockBasedTableOptions struct {
 
 	// We keep these so we can free their memory in Destroy.
 	cFp *C.rocksdb_filterpolicy_t
+
+	// The value given to SetFilterPolicy.
+	fp FilterPolicy
+
+	// The C API has no getters, so we keep track of the values set.
+	blockSize            int
+	blockSizeDeviation   int
+	blockRestartInterval int
+	noBlockCache         bool
+	wholeKeyFiltering    bool
+
+	indexType                                 IndexType
+	partitionFilters                          bool
+	metadataBlockSize                         uint64
+	cacheIndexAndFilterBlocks                 bool
+	cacheIndexAndFilterBlocksWithHighPriority bool
+	pinL0FilterAndIndexBlocksInCache          bool
+	pinTopLevelIndexAndFilter                 bool
+	formatVersion                             int
+	dataBlockIndexType                        DataBlockIndexType
+	dataBlockHashRatio                        float64
 }
 
 // NewDefaultBlockBasedTableOptions creates a default BlockBasedTableOptions object.
@@ -22,8 +77,21 @@ func NewDefaultBlockBasedTableOptions() *BlockBasedTableOptions {
 }
 
 // NewNativeBlockBasedTableOptions creates a BlockBasedTableOptions object.
+// The getters assume that c still has RocksDB's default values.
 func NewNativeBlockBasedTableOptions(c *C.rocksdb_block_based_table_options_t) *BlockBasedTableOptions {
-	return &BlockBasedTableOptions{c: c}
+	return &BlockBasedTableOptions{
+		c:                    c,
+		blockSize:            4 << 10,
+		blockSizeDeviation:   10,
+		blockRestartInterval: 16,
+		wholeKeyFiltering:    true,
+		metadataBlockSize:    4 << 10,
+		formatVersion:        5,
+		dataBlockHashRatio:   0.75,
+
+		cacheIndexAndFilterBlocksWithHighPriority: true,
+		pinTopLevelIndexAndFilter:                 true,
+	}
 }
 
 // Destroy deallocates the BlockBasedTableOptions object.
@@ -40,9 +108,15 @@ func (opts *BlockBasedTableOptions) Destroy() {
 // compression is enabled. This parameter can be changed dynamically.
 // Default: 4K
 func (opts *BlockBasedTableOptions) SetBlockSize(blockSize int) {
+	opts.blockSize = blockSize
 	C.rocksdb_block_based_options_set_block_size(opts.c, C.size_t(blockSize))
 }
 
+// GetBlockSize returns the approximate size of user data packed per block.
+func (opts *BlockBasedTableOptions) GetBlockSize() int {
+	return opts.blockSize
+}
+
 // SetBlockSizeDeviation sets the block size deviation.
 // This is used opts close a block before it reaches the configured
 // 'block_size'. If the percentage of free space in the current block is less
@@ -51,23 +125,37 @@ func (opts *BlockBasedTableOptions) SetBlockSize(blockSize int) {
 // new record will be written opts the next block.
 // Default: 10
 func (opts *BlockBasedTableOptions) SetBlockSizeDeviation(blockSizeDeviation int) {
+	opts.blockSizeDeviation = blockSizeDeviation
 	C.rocksdb_block_based_options_set_block_size_deviation(opts.c, C.int(blockSizeDeviation))
 }
 
+// GetBlockSizeDeviation returns the block size deviation.
+func (opts *BlockBasedTableOptions) GetBlockSizeDeviation() int {
+	return opts.blockSizeDeviation
+}
+
 // SetBlockRestartInterval sets the number of keys between
 // restart points for delta encoding of keys.
 // This parameter can be changed dynamically. Most clients should
 // leave this parameter alone.
 // Default: 16
 func (opts *BlockBasedTableOptions) SetBlockRestartInterval(blockRestartInterval int) {
+	opts.blockRestartInterval = blockRestartInterval
 	C.rocksdb_block_based_options_set_block_restart_interval(opts.c, C.int(blockRestartInterval))
 }
 
+// GetBlockRestartInterval returns the number of keys between
+// restart points for delta encoding of keys.
+func (opts *BlockBasedTableOptions) GetBlockRestartInterval() int {
+	return opts.blockRestartInterval
+}
+
 // SetFilterPolicy sets the filter policy opts reduce disk reads.
 // Many applications will benefit from passing the result of
 // NewBloomFilterPolicy() here.
 // Default: nil
 func (opts *BlockBasedTableOptions) SetFilterPolicy(fp FilterPolicy) {
+	opts.fp = fp
 	if nfp, ok := fp.(nativeFilterPolicy); ok {
 		opts.cFp = nfp.c
 	} else {
@@ -77,12 +165,23 @@ func (opts *BlockBasedTableOptions) SetFilterPolicy(fp FilterPolicy) {
 	C.rocksdb_block_based_options_set_filter_policy(opts.c, opts.cFp)
 }
 
+// GetFilterPolicy returns the filter policy, nil if none was set.
+func (opts *BlockBasedTableOptions) GetFilterPolicy() FilterPolicy {
+	return opts.fp
+}
+
 // SetNoBlockCache specify whether block cache should be used or not.
 // Default: false
 func (opts *BlockBasedTableOptions) SetNoBlockCache(value bool) {
+	opts.noBlockCache = value
 	C.rocksdb_block_based_options_set_no_block_cache(opts.c, boolToChar(value))
 }
 
+// GetNoBlockCache returns whether the block cache is disabled.
+func (opts *BlockBasedTableOptions) GetNoBlockCache() bool {
+	return opts.noBlockCache
+}
+
 // SetBlockCache sets the control over blocks (user data is stored in a set of blocks, and
 // a block is the unit of reading from disk).
 //
@@ -94,6 +193,11 @@ func (opts *BlockBasedTableOptions) SetBlockCache(cache *Cache) {
 	C.rocksdb_block_based_options_set_block_cache(opts.c, cache.c)
 }
 
+// GetBlockCache returns the cache for blocks, nil if none was set.
+func (opts *BlockBasedTableOptions) GetBlockCache() *Cache {
+	return opts.cache
+}
+
 // SetBlockCacheCompressed sets the cache for compressed blocks.
 // If nil, rocksdb will not use a compressed block cache.
 // Default: nil
@@ -102,10 +206,164 @@ func (opts *BlockBasedTableOptions) SetBlockCacheCompressed(cache *Cache) {
 	C.rocksdb_block_based_options_set_block_cache_compressed(opts.c, cache.c)
 }
 
+// GetBlockCacheCompressed returns the cache for compressed blocks,
+// nil if none was set.
+func (opts *BlockBasedTableOptions) GetBlockCacheCompressed() *Cache {
+	return opts.compCache
+}
+
 // SetWholeKeyFiltering specify if whole keys in the filter (not just prefixes)
 // should be placed.
 // This must generally be true for gets opts be efficient.
 // Default: true
 func (opts *BlockBasedTableOptions) SetWholeKeyFiltering(value bool) {
+	opts.wholeKeyFiltering = value
 	C.rocksdb_block_based_options_set_whole_key_filtering(opts.c, boolToChar(value))
 }
+
+// GetWholeKeyFiltering returns whether whole keys are placed in the filter.
+func (opts *BlockBasedTableOptions) GetWholeKeyFiltering() bool {
+	return opts.wholeKeyFiltering
+}
+
+// SetIndexType sets the index type used for this table.
+// KHashSearchIndexType requires a prefix extractor, see
+// Options.SetPrefixExtractor.
+// Default: KBinarySearchIndexType
+func (opts *BlockBasedTableOptions) SetIndexType(value IndexType) {
+	opts.indexType = value
+	C.rocksdb_block_based_options_set_index_type(opts.c, C.int(value))
+}
+
+// GetIndexType returns the index type used for this table.
+func (opts *BlockBasedTableOptions) GetIndexType() IndexType {
+	return opts.indexType
+}
+
+// SetPartitionFilters specify whether the filter is partitioned like the
+// index. It requires KTwoLevelIndexSearchIndexType and a full filter
+// policy, the filter and index partitions can then be loaded into the
+// block cache on demand.
+// Default: false
+func (opts *BlockBasedTableOptions) SetPartitionFilters(value bool) {
+	opts.partitionFilters = value
+	C.rocksdb_block_based_options_set_partition_filters(opts.c, boolToChar(value))
+}
+
+// GetPartitionFilters returns whether the filter is partitioned.
+func (opts *BlockBasedTableOptions) GetPartitionFilters() bool {
+	return opts.partitionFilters
+}
+
+// SetMetadataBlockSize sets the target size of the index and filter
+// partitions if KTwoLevelIndexSearchIndexType is used.
+// Default: 4K
+func (opts *BlockBasedTableOptions) SetMetadataBlockSize(value uint64) {
+	opts.metadataBlockSize = value
+	C.rocksdb_block_based_options_set_metadata_block_size(opts.c, C.uint64_t(value))
+}
+
+// GetMetadataBlockSize returns the target size of the index and filter
+// partitions.
+func (opts *BlockBasedTableOptions) GetMetadataBlockSize() uint64 {
+	return opts.metadataBlockSize
+}
+
+// SetCacheIndexAndFilterBlocks specify whether index and filter blocks are
+// put into the block cache. If false, they are kept in memory for as long
+// as the table is open, outside of the block cache.
+// Default: false
+func (opts *BlockBasedTableOptions) SetCacheIndexAndFilterBlocks(value bool) {
+	opts.cacheIndexAndFilterBlocks = value
+	C.rocksdb_block_based_options_set_cache_index_and_filter_blocks(opts.c, boolToChar(value))
+}
+
+// GetCacheIndexAndFilterBlocks returns whether index and filter blocks are
+// put into the block cache.
+func (opts *BlockBasedTableOptions) GetCacheIndexAndFilterBlocks() bool {
+	return opts.cacheIndexAndFilterBlocks
+}
+
+// SetCacheIndexAndFilterBlocksWithHighPriority specify whether index and
+// filter blocks are put into the high priority pool of the block cache.
+// It only has an effect if SetCacheIndexAndFilterBlocks is true.
+// Default: true
+func (opts *BlockBasedTableOptions) SetCacheIndexAndFilterBlocksWithHighPriority(value bool) {
+	opts.cacheIndexAndFilterBlocksWithHighPriority = value
+	C.rocksdb_block_based_options_set_cache_index_and_filter_blocks_with_high_priority(opts.c, boolToChar(value))
+}
+
+// GetCacheIndexAndFilterBlocksWithHighPriority returns whether index and
+// filter blocks are put into the high priority pool of the block cache.
+func (opts *BlockBasedTableOptions) GetCacheIndexAndFilterBlocksWithHighPriority() bool {
+	return opts.cacheIndexAndFilterBlocksWithHighPriority
+}
+
+// SetPinL0FilterAndIndexBlocksInCache specify whether the index and filter
+// blocks of level 0 files are pinned in the block cache.
+// It only has an effect if SetCacheIndexAndFilterBlocks is true.
+// Default: false
+func (opts *BlockBasedTableOptions) SetPinL0FilterAndIndexBlocksInCache(value bool) {
+	opts.pinL0FilterAndIndexBlocksInCache = value
+	C.rocksdb_block_based_options_set_pin_l0_filter_and_index_blocks_in_cache(opts.c, boolToChar(value))
+}
+
+// GetPinL0FilterAndIndexBlocksInCache returns whether the index and filter
+// blocks of level 0 files are pinned in the block cache.
+func (opts *BlockBasedTableOptions) GetPinL0FilterAndIndexBlocksInCache() bool {
+	return opts.pinL0FilterAndIndexBlocksInCache
+}
+
+// SetPinTopLevelIndexAndFilter specify whether the top level index of
+// partitioned index and filter blocks is pinned in the block cache.
+// Default: true
+func (opts *BlockBasedTableOptions) SetPinTopLevelIndexAndFilter(value bool) {
+	opts.pinTopLevelIndexAndFilter = value
+	C.rocksdb_block_based_options_set_pin_top_level_index_and_filter(opts.c, boolToChar(value))
+}
+
+// GetPinTopLevelIndexAndFilter returns whether the top level index of
+// partitioned index and filter blocks is pinned in the block cache.
+func (opts *BlockBasedTableOptions) GetPinTopLevelIndexAndFilter() bool {
+	return opts.pinTopLevelIndexAndFilter
+}
+
+// SetFormatVersion sets the format version of new tables. Newer versions
+// are more efficient but cannot be read by old RocksDB releases.
+// Default: 5
+func (opts *BlockBasedTableOptions) SetFormatVersion(value int) {
+	opts.formatVersion = value
+	C.rocksdb_block_based_options_set_format_version(opts.c, C.int(value))
+}
+
+// GetFormatVersion returns the format version of new tables.
+func (opts *BlockBasedTableOptions) GetFormatVersion() int {
+	return opts.formatVersion
+}
+
+// SetDataBlockIndexType sets the index type of data blocks.
+// Default: KDataBlockIndexTypeBinarySearch
+func (opts *BlockBasedTableOptions) SetDataBlockIndexType(value DataBlockIndexType) {
+	opts.dataBlockIndexType = value
+	C.rocksdb_block_based_options_set_data_block_index_type(opts.c, C.int(value))
+}
+
+// GetDataBlockIndexType returns the index type of data blocks.
+func (opts *BlockBasedTableOptions) GetDataBlockIndexType() DataBlockIndexType {
+	return opts.dataBlockIndexType
+}
+
+// SetDataBlockHashRatio sets the ratio of keys to hash buckets of the data
+// block hash index. It only has an effect with
+// KDataBlockIndexTypeBinarySearchAndHash.
+// Default: 0.75
+func (opts *BlockBasedTableOptions) SetDataBlockHashRatio(value float64) {
+	opts.dataBlockHashRatio = value
+	C.rocksdb_block_based_options_set_data_block_hash_ratio(opts.c, C.double(value))
+}
+
+// GetDataBlockHashRatio returns the ratio of keys to hash buckets of the
+// data block hash index.
+func (opts *BlockBasedTableOptions) GetDataBlockHashRatio() float64 {
+	return opts.dataBlockHashRatio
+}
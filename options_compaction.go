@@ -37,9 +37,15 @@ func (opts *FIFOCompactionOptions) SetMaxTableFilesSize(value uint64) {
 	C.rocksdb_fifo_compaction_options_set_max_table_files_size(opts.c, C.uint64_t(value))
 }
 
+// GetMaxTableFilesSize returns the max table file size.
+func (opts *FIFOCompactionOptions) GetMaxTableFilesSize() uint64 {
+	return uint64(C.rocksdb_fifo_compaction_options_get_max_table_files_size(opts.c))
+}
+
 // Destroy deallocates the FIFOCompactionOptions object.
 func (opts *FIFOCompactionOptions) Destroy() {
 	C.rocksdb_fifo_compaction_options_destroy(opts.c)
+	opts.c = nil
 }
 
 // UniversalCompactionOptions represent all of the available options for
@@ -68,18 +74,33 @@ func (opts *UniversalCompactionOptions) SetSizeRatio(value uint) {
 	C.rocksdb_universal_compaction_options_set_size_ratio(opts.c, C.int(value))
 }
 
+// GetSizeRatio returns the percentage flexibilty while comparing file size.
+func (opts *UniversalCompactionOptions) GetSizeRatio() uint {
+	return uint(C.rocksdb_universal_compaction_options_get_size_ratio(opts.c))
+}
+
 // SetMinMergeWidth sets the minimum number of files in a single compaction run.
 // Default: 2
 func (opts *UniversalCompactionOptions) SetMinMergeWidth(value uint) {
 	C.rocksdb_universal_compaction_options_set_min_merge_width(opts.c, C.int(value))
 }
 
+// GetMinMergeWidth returns the minimum number of files in a single compaction run.
+func (opts *UniversalCompactionOptions) GetMinMergeWidth() uint {
+	return uint(C.rocksdb_universal_compaction_options_get_min_merge_width(opts.c))
+}
+
 // SetMaxMergeWidth sets the maximum number of files in a single compaction run.
 // Default: UINT_MAX
 func (opts *UniversalCompactionOptions) SetMaxMergeWidth(value uint) {
 	C.rocksdb_universal_compaction_options_set_max_merge_width(opts.c, C.int(value))
 }
 
+// GetMaxMergeWidth returns the maximum number of files in a single compaction run.
+func (opts *UniversalCompactionOptions) GetMaxMergeWidth() uint {
+	return uint(C.rocksdb_universal_compaction_options_get_max_merge_width(opts.c))
+}
+
 // SetMaxSizeAmplificationPercent sets the size amplification.
 // It is defined as the amount (in percentage) of
 // additional storage needed to store a single byte of data in the database.
@@ -95,6 +116,11 @@ func (opts *UniversalCompactionOptions) SetMaxSizeAmplificationPercent(value uin
 	C.rocksdb_universal_compaction_options_set_max_size_amplification_percent(opts.c, C.int(value))
 }
 
+// GetMaxSizeAmplificationPercent returns the size amplification.
+func (opts *UniversalCompactionOptions) GetMaxSizeAmplificationPercent() uint {
+	return uint(C.rocksdb_universal_compaction_options_get_max_size_amplification_percent(opts.c))
+}
+
 // SetCompressionSizePercent sets the percentage of compression size.
 //
 // If this option is set to be -1, all the output files
@@ -117,12 +143,22 @@ func (opts *UniversalCompactionOptions) SetCompressionSizePercent(value int) {
 	C.rocksdb_universal_compaction_options_set_compression_size_percent(opts.c, C.int(value))
 }
 
+// GetCompressionSizePercent returns the percentage of compression size.
+func (opts *UniversalCompactionOptions) GetCompressionSizePercent() int {
+	return int(C.rocksdb_universal_compaction_options_get_compression_size_percent(opts.c))
+}
+
 // SetStopStyle sets the algorithm used to stop picking files into a single compaction run.
 // Default: CompactionStopStyleTotalSize
 func (opts *UniversalCompactionOptions) SetStopStyle(value UniversalCompactionStopStyle) {
 	C.rocksdb_universal_compaction_options_set_stop_style(opts.c, C.int(value))
 }
 
+// GetStopStyle returns the algorithm used to stop picking files into a single compaction run.
+func (opts *UniversalCompactionOptions) GetStopStyle() UniversalCompactionStopStyle {
+	return UniversalCompactionStopStyle(C.rocksdb_universal_compaction_options_get_stop_style(opts.c))
+}
+
 // Destroy deallocates the UniversalCompactionOptions object.
 func (opts *UniversalCompactionOptions) Destroy() {
 	C.rocksdb_universal_compaction_options_destroy(opts.c)
@@ -1,11 +1,20 @@
 package gorocksdb
 
+import (
+	"strconv"
+	"sync"
+)
+
 // CompressionOptions represents options for different compression algorithms like Zlib.
 type CompressionOptions struct {
-	WindowBits   int
-	Level        int
-	Strategy     int
-	MaxDictBytes int
+	WindowBits   int `json:"window_bits" yaml:"window_bits"`
+	Level        int `json:"level" yaml:"level"`
+	Strategy     int `json:"strategy" yaml:"strategy"`
+	MaxDictBytes int `json:"max_dict_bytes" yaml:"max_dict_bytes"`
+	// ZstdMaxTrainBytes is the maximum size of the samples used to train a
+	// ZSTD dictionary of MaxDictBytes. If 0, the samples are used as
+	// dictionary without training.
+	ZstdMaxTrainBytes int `json:"zstd_max_train_bytes" yaml:"zstd_max_train_bytes"`
 }
 
 // NewDefaultCompressionOptions creates a default CompressionOptions object.
@@ -22,3 +31,47 @@ func NewCompressionOptions(windowBits, level, strategy, maxDictBytes int) *Compr
 		MaxDictBytes: maxDictBytes,
 	}
 }
+
+var (
+	supportedCompressionsOnce sync.Once
+	supportedCompressions     []CompressionType
+)
+
+// SupportedCompressions returns the compression types the linked RocksDB
+// library was built with. NoCompression is always supported.
+//
+// The C API of RocksDB cannot report them, so they are probed once by
+// opening an in-memory database with each compression type.
+func SupportedCompressions() []CompressionType {
+	supportedCompressionsOnce.Do(func() {
+		supportedCompressions = []CompressionType{NoCompression}
+		for _, value := range []CompressionType{
+			SnappyCompression, ZLibCompression, Bz2Compression, LZ4Compression,
+			LZ4HCCompression, XpressCompression, ZSTDCompression,
+		} {
+			if compressionSupported(value) {
+				supportedCompressions = append(supportedCompressions, value)
+			}
+		}
+	})
+	return append([]CompressionType(nil), supportedCompressions...)
+}
+
+// compressionSupported reports whether a database can be opened with the
+// given compression type, RocksDB rejects types it was built without.
+func compressionSupported(value CompressionType) bool {
+	env := NewMemEnv()
+	defer env.Destroy()
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	opts.SetEnv(env)
+	opts.SetCreateIfMissing(true)
+	opts.SetCompression(value)
+
+	db, err := OpenDb(opts, "/gorocksdb-compression-"+strconv.Itoa(int(value)))
+	if err != nil {
+		return false
+	}
+	db.Close()
+	return true
+}
@@ -0,0 +1,347 @@
+package gorocksdb
+
+import "fmt"
+
+// Config is a plain, serializable representation of Options. It can be
+// marshaled to JSON or YAML to keep the configuration of a database under
+// version control, and turned into Options with Build.
+//
+// Callbacks like comparators, merge operators, compaction filters, slice
+// transforms and filter policies, as well as envs and caches shared between
+// Options, are not part of a Config and have to be set on the built Options.
+//
+// BottommostCompression is nil if the bottommost level uses the compression
+// of the other levels, which is the default.
+type Config struct {
+	CreateIfMissing                 bool                    `json:"create_if_missing" yaml:"create_if_missing"`
+	CreateIfMissingColumnFamilies   bool                    `json:"create_missing_column_families" yaml:"create_missing_column_families"`
+	ErrorIfExists                   bool                    `json:"error_if_exists" yaml:"error_if_exists"`
+	ParanoidChecks                  bool                    `json:"paranoid_checks" yaml:"paranoid_checks"`
+	InfoLogLevel                    InfoLogLevel            `json:"info_log_level" yaml:"info_log_level"`
+	AllowConcurrentMemtableWrites   bool                    `json:"allow_concurrent_memtable_write" yaml:"allow_concurrent_memtable_write"`
+	WriteBufferSize                 int                     `json:"write_buffer_size" yaml:"write_buffer_size"`
+	MaxWriteBufferNumber            int                     `json:"max_write_buffer_number" yaml:"max_write_buffer_number"`
+	MinWriteBufferNumberToMerge     int                     `json:"min_write_buffer_number_to_merge" yaml:"min_write_buffer_number_to_merge"`
+	MaxOpenFiles                    int                     `json:"max_open_files" yaml:"max_open_files"`
+	Compression                     CompressionType         `json:"compression" yaml:"compression"`
+	CompressionOptions              CompressionOptions      `json:"compression_opts" yaml:"compression_opts"`
+	BottommostCompression           *CompressionType        `json:"bottommost_compression,omitempty" yaml:"bottommost_compression,omitempty"`
+	NumLevels                       int                     `json:"num_levels" yaml:"num_levels"`
+	Level0FileNumCompactionTrigger  int                     `json:"level0_file_num_compaction_trigger" yaml:"level0_file_num_compaction_trigger"`
+	Level0SlowdownWritesTrigger     int                     `json:"level0_slowdown_writes_trigger" yaml:"level0_slowdown_writes_trigger"`
+	Level0StopWritesTrigger         int                     `json:"level0_stop_writes_trigger" yaml:"level0_stop_writes_trigger"`
+	TargetFileSizeBase              uint64                  `json:"target_file_size_base" yaml:"target_file_size_base"`
+	TargetFileSizeMultiplier        int                     `json:"target_file_size_multiplier" yaml:"target_file_size_multiplier"`
+	MaxBytesForLevelBase            uint64                  `json:"max_bytes_for_level_base" yaml:"max_bytes_for_level_base"`
+	MaxBytesForLevelMultiplier      float64                 `json:"max_bytes_for_level_multiplier" yaml:"max_bytes_for_level_multiplier"`
+	UseFsync                        bool                    `json:"use_fsync" yaml:"use_fsync"`
+	DeleteObsoleteFilesPeriodMicros uint64                  `json:"delete_obsolete_files_period_micros" yaml:"delete_obsolete_files_period_micros"`
+	MaxBackgroundCompactions        int                     `json:"max_background_compactions" yaml:"max_background_compactions"`
+	MaxBackgroundFlushes            int                     `json:"max_background_flushes" yaml:"max_background_flushes"`
+	MaxLogFileSize                  int                     `json:"max_log_file_size" yaml:"max_log_file_size"`
+	LogFileTimeToRoll               int                     `json:"log_file_time_to_roll" yaml:"log_file_time_to_roll"`
+	KeepLogFileNum                  int                     `json:"keep_log_file_num" yaml:"keep_log_file_num"`
+	MaxManifestFileSize             uint64                  `json:"max_manifest_file_size" yaml:"max_manifest_file_size"`
+	TableCacheNumshardbits          int                     `json:"table_cache_numshardbits" yaml:"table_cache_numshardbits"`
+	ArenaBlockSize                  int                     `json:"arena_block_size" yaml:"arena_block_size"`
+	DisableAutoCompactions          bool                    `json:"disable_auto_compactions" yaml:"disable_auto_compactions"`
+	WALTtlSeconds                   uint64                  `json:"wal_ttl_seconds" yaml:"wal_ttl_seconds"`
+	WalSizeLimitMb                  uint64                  `json:"wal_size_limit_mb" yaml:"wal_size_limit_mb"`
+	ManifestPreallocationSize       int                     `json:"manifest_preallocation_size" yaml:"manifest_preallocation_size"`
+	AllowMmapReads                  bool                    `json:"allow_mmap_reads" yaml:"allow_mmap_reads"`
+	AllowMmapWrites                 bool                    `json:"allow_mmap_writes" yaml:"allow_mmap_writes"`
+	UseDirectReads                  bool                    `json:"use_direct_reads" yaml:"use_direct_reads"`
+	IsFdCloseOnExec                 bool                    `json:"is_fd_close_on_exec" yaml:"is_fd_close_on_exec"`
+	StatsDumpPeriodSec              uint                    `json:"stats_dump_period_sec" yaml:"stats_dump_period_sec"`
+	AdviseRandomOnOpen              bool                    `json:"advise_random_on_open" yaml:"advise_random_on_open"`
+	AccessHintOnCompactionStart     CompactionAccessPattern `json:"access_hint_on_compaction_start" yaml:"access_hint_on_compaction_start"`
+	UseAdaptiveMutex                bool                    `json:"use_adaptive_mutex" yaml:"use_adaptive_mutex"`
+	BytesPerSync                    uint64                  `json:"bytes_per_sync" yaml:"bytes_per_sync"`
+	CompactionStyle                 CompactionStyle         `json:"compaction_style" yaml:"compaction_style"`
+	MaxSequentialSkipInIterations   uint64                  `json:"max_sequential_skip_in_iterations" yaml:"max_sequential_skip_in_iterations"`
+	InplaceUpdateSupport            bool                    `json:"inplace_update_support" yaml:"inplace_update_support"`
+	InplaceUpdateNumLocks           int                     `json:"inplace_update_num_locks" yaml:"inplace_update_num_locks"`
+	BloomLocality                   uint32                  `json:"bloom_locality" yaml:"bloom_locality"`
+	MaxSuccessiveMerges             int                     `json:"max_successive_merges" yaml:"max_successive_merges"`
+
+	// BlockBasedTable configures the block based table factory.
+	// If nil, the default table factory is used.
+	BlockBasedTable *BlockBasedTableConfig `json:"block_based_table,omitempty" yaml:"block_based_table,omitempty"`
+	// UniversalCompaction configures the universal compaction style.
+	// If nil, the defaults are used.
+	UniversalCompaction *UniversalCompactionConfig `json:"universal_compaction,omitempty" yaml:"universal_compaction,omitempty"`
+	// FIFOCompaction configures the FIFO compaction style.
+	// If nil, the defaults are used.
+	FIFOCompaction *FIFOCompactionConfig `json:"fifo_compaction,omitempty" yaml:"fifo_compaction,omitempty"`
+}
+
+// BlockBasedTableConfig is a plain representation of BlockBasedTableOptions.
+type BlockBasedTableConfig struct {
+	BlockSize            int  `json:"block_size" yaml:"block_size"`
+	BlockSizeDeviation   int  `json:"block_size_deviation" yaml:"block_size_deviation"`
+	BlockRestartInterval int  `json:"block_restart_interval" yaml:"block_restart_interval"`
+	NoBlockCache         bool `json:"no_block_cache" yaml:"no_block_cache"`
+	// BlockCacheSize is the capacity of a LRU block cache created by Build.
+	// If 0, the default block cache is used.
+	BlockCacheSize    int  `json:"block_cache_size,omitempty" yaml:"block_cache_size,omitempty"`
+	WholeKeyFiltering bool `json:"whole_key_filtering" yaml:"whole_key_filtering"`
+}
+
+// UniversalCompactionConfig is a plain representation of
+// UniversalCompactionOptions.
+type UniversalCompactionConfig struct {
+	SizeRatio                   uint                         `json:"size_ratio" yaml:"size_ratio"`
+	MinMergeWidth               uint                         `json:"min_merge_width" yaml:"min_merge_width"`
+	MaxMergeWidth               uint                         `json:"max_merge_width" yaml:"max_merge_width"`
+	MaxSizeAmplificationPercent uint                         `json:"max_size_amplification_percent" yaml:"max_size_amplification_percent"`
+	CompressionSizePercent      int                          `json:"compression_size_percent" yaml:"compression_size_percent"`
+	StopStyle                   UniversalCompactionStopStyle `json:"stop_style" yaml:"stop_style"`
+}
+
+// FIFOCompactionConfig is a plain representation of FIFOCompactionOptions.
+type FIFOCompactionConfig struct {
+	MaxTableFilesSize uint64 `json:"max_table_files_size" yaml:"max_table_files_size"`
+}
+
+// NewDefaultConfig creates a Config holding the default options.
+func NewDefaultConfig() *Config {
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	return FromOptions(opts)
+}
+
+// FromOptions creates a Config from the given Options.
+// The block based table and compaction style options are only included
+// if they were set with SetBlockBasedTableFactory,
+// SetUniversalCompactionOptions or SetFIFOCompactionOptions.
+func FromOptions(opts *Options) *Config {
+	c := &Config{
+		CreateIfMissing:                 opts.GetCreateIfMissing(),
+		CreateIfMissingColumnFamilies:   opts.GetCreateIfMissingColumnFamilies(),
+		ErrorIfExists:                   opts.GetErrorIfExists(),
+		ParanoidChecks:                  opts.GetParanoidChecks(),
+		InfoLogLevel:                    opts.GetInfoLogLevel(),
+		AllowConcurrentMemtableWrites:   opts.GetAllowConcurrentMemtableWrites(),
+		WriteBufferSize:                 opts.GetWriteBufferSize(),
+		MaxWriteBufferNumber:            opts.GetMaxWriteBufferNumber(),
+		MinWriteBufferNumberToMerge:     opts.GetMinWriteBufferNumberToMerge(),
+		MaxOpenFiles:                    opts.GetMaxOpenFiles(),
+		Compression:                     opts.GetCompression(),
+		CompressionOptions:              *opts.GetCompressionOptions(),
+		NumLevels:                       opts.GetNumLevels(),
+		Level0FileNumCompactionTrigger:  opts.GetLevel0FileNumCompactionTrigger(),
+		Level0SlowdownWritesTrigger:     opts.GetLevel0SlowdownWritesTrigger(),
+		Level0StopWritesTrigger:         opts.GetLevel0StopWritesTrigger(),
+		TargetFileSizeBase:              opts.GetTargetFileSizeBase(),
+		TargetFileSizeMultiplier:        opts.GetTargetFileSizeMultiplier(),
+		MaxBytesForLevelBase:            opts.GetMaxBytesForLevelBase(),
+		MaxBytesForLevelMultiplier:      opts.GetMaxBytesForLevelMultiplier(),
+		UseFsync:                        opts.GetUseFsync(),
+		DeleteObsoleteFilesPeriodMicros: opts.GetDeleteObsoleteFilesPeriodMicros(),
+		MaxBackgroundCompactions:        opts.GetMaxBackgroundCompactions(),
+		MaxBackgroundFlushes:            opts.GetMaxBackgroundFlushes(),
+		MaxLogFileSize:                  opts.GetMaxLogFileSize(),
+		LogFileTimeToRoll:               opts.GetLogFileTimeToRoll(),
+		KeepLogFileNum:                  opts.GetKeepLogFileNum(),
+		MaxManifestFileSize:             opts.GetMaxManifestFileSize(),
+		TableCacheNumshardbits:          opts.GetTableCacheNumshardbits(),
+		ArenaBlockSize:                  opts.GetArenaBlockSize(),
+		DisableAutoCompactions:          opts.GetDisableAutoCompactions(),
+		WALTtlSeconds:                   opts.GetWALTtlSeconds(),
+		WalSizeLimitMb:                  opts.GetWalSizeLimitMb(),
+		ManifestPreallocationSize:       opts.GetManifestPreallocationSize(),
+		AllowMmapReads:                  opts.GetAllowMmapReads(),
+		AllowMmapWrites:                 opts.GetAllowMmapWrites(),
+		UseDirectReads:                  opts.GetUseDirectReads(),
+		IsFdCloseOnExec:                 opts.GetIsFdCloseOnExec(),
+		StatsDumpPeriodSec:              opts.GetStatsDumpPeriodSec(),
+		AdviseRandomOnOpen:              opts.GetAdviseRandomOnOpen(),
+		AccessHintOnCompactionStart:     opts.GetAccessHintOnCompactionStart(),
+		UseAdaptiveMutex:                opts.GetUseAdaptiveMutex(),
+		BytesPerSync:                    opts.GetBytesPerSync(),
+		CompactionStyle:                 opts.GetCompactionStyle(),
+		MaxSequentialSkipInIterations:   opts.GetMaxSequentialSkipInIterations(),
+		InplaceUpdateSupport:            opts.GetInplaceUpdateSupport(),
+		InplaceUpdateNumLocks:           opts.GetInplaceUpdateNumLocks(),
+		BloomLocality:                   opts.GetBloomLocality(),
+		MaxSuccessiveMerges:             opts.GetMaxSuccessiveMerges(),
+	}
+	if value := opts.GetBottommostCompression(); value != DisableCompressionOption {
+		c.BottommostCompression = &value
+	}
+	if bbto := opts.bbto; bbto != nil && bbto.c != nil {
+		c.BlockBasedTable = &BlockBasedTableConfig{
+			BlockSize:            bbto.GetBlockSize(),
+			BlockSizeDeviation:   bbto.GetBlockSizeDeviation(),
+			BlockRestartInterval: bbto.GetBlockRestartInterval(),
+			NoBlockCache:         bbto.GetNoBlockCache(),
+			WholeKeyFiltering:    bbto.GetWholeKeyFiltering(),
+		}
+		if cache := bbto.GetBlockCache(); cache != nil && cache.c != nil {
+			c.BlockBasedTable.BlockCacheSize = cache.GetCapacity()
+		}
+	}
+	if uco := opts.uco; uco != nil && uco.c != nil {
+		c.UniversalCompaction = &UniversalCompactionConfig{
+			SizeRatio:                   uco.GetSizeRatio(),
+			MinMergeWidth:               uco.GetMinMergeWidth(),
+			MaxMergeWidth:               uco.GetMaxMergeWidth(),
+			MaxSizeAmplificationPercent: uco.GetMaxSizeAmplificationPercent(),
+			CompressionSizePercent:      uco.GetCompressionSizePercent(),
+			StopStyle:                   uco.GetStopStyle(),
+		}
+	}
+	if fco := opts.fco; fco != nil && fco.c != nil {
+		c.FIFOCompaction = &FIFOCompactionConfig{
+			MaxTableFilesSize: fco.GetMaxTableFilesSize(),
+		}
+	}
+	return c
+}
+
+// Build creates an Options object from the Config. The block based table
+// options, block cache and compaction options it creates are owned by the
+// Options and destroyed together with it and its clones.
+func (c *Config) Build() (*Options, error) {
+	if err := c.validate(); err != nil {
+		return nil, err
+	}
+
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissing(c.CreateIfMissing)
+	opts.SetCreateIfMissingColumnFamilies(c.CreateIfMissingColumnFamilies)
+	opts.SetErrorIfExists(c.ErrorIfExists)
+	opts.SetParanoidChecks(c.ParanoidChecks)
+	opts.SetInfoLogLevel(c.InfoLogLevel)
+	opts.SetAllowConcurrentMemtableWrites(c.AllowConcurrentMemtableWrites)
+	opts.SetWriteBufferSize(c.WriteBufferSize)
+	opts.SetMaxWriteBufferNumber(c.MaxWriteBufferNumber)
+	opts.SetMinWriteBufferNumberToMerge(c.MinWriteBufferNumberToMerge)
+	opts.SetMaxOpenFiles(c.MaxOpenFiles)
+	opts.SetCompression(c.Compression)
+	opts.SetCompressionOptions(&c.CompressionOptions)
+	if c.BottommostCompression != nil {
+		opts.SetBottommostCompression(*c.BottommostCompression)
+	}
+	opts.SetNumLevels(c.NumLevels)
+	opts.SetLevel0FileNumCompactionTrigger(c.Level0FileNumCompactionTrigger)
+	opts.SetLevel0SlowdownWritesTrigger(c.Level0SlowdownWritesTrigger)
+	opts.SetLevel0StopWritesTrigger(c.Level0StopWritesTrigger)
+	opts.SetTargetFileSizeBase(c.TargetFileSizeBase)
+	opts.SetTargetFileSizeMultiplier(c.TargetFileSizeMultiplier)
+	opts.SetMaxBytesForLevelBase(c.MaxBytesForLevelBase)
+	opts.SetMaxBytesForLevelMultiplier(c.MaxBytesForLevelMultiplier)
+	opts.SetUseFsync(c.UseFsync)
+	opts.SetDeleteObsoleteFilesPeriodMicros(c.DeleteObsoleteFilesPeriodMicros)
+	opts.SetMaxBackgroundCompactions(c.MaxBackgroundCompactions)
+	opts.SetMaxBackgroundFlushes(c.MaxBackgroundFlushes)
+	opts.SetMaxLogFileSize(c.MaxLogFileSize)
+	opts.SetLogFileTimeToRoll(c.LogFileTimeToRoll)
+	opts.SetKeepLogFileNum(c.KeepLogFileNum)
+	opts.SetMaxManifestFileSize(c.MaxManifestFileSize)
+	opts.SetTableCacheNumshardbits(c.TableCacheNumshardbits)
+	opts.SetArenaBlockSize(c.ArenaBlockSize)
+	opts.SetDisableAutoCompactions(c.DisableAutoCompactions)
+	opts.SetWALTtlSeconds(c.WALTtlSeconds)
+	opts.SetWalSizeLimitMb(c.WalSizeLimitMb)
+	opts.SetManifestPreallocationSize(c.ManifestPreallocationSize)
+	opts.SetAllowMmapReads(c.AllowMmapReads)
+	opts.SetAllowMmapWrites(c.AllowMmapWrites)
+	opts.SetUseDirectReads(c.UseDirectReads)
+	opts.SetIsFdCloseOnExec(c.IsFdCloseOnExec)
+	opts.SetStatsDumpPeriodSec(c.StatsDumpPeriodSec)
+	opts.SetAdviseRandomOnOpen(c.AdviseRandomOnOpen)
+	opts.SetAccessHintOnCompactionStart(c.AccessHintOnCompactionStart)
+	opts.SetUseAdaptiveMutex(c.UseAdaptiveMutex)
+	opts.SetBytesPerSync(c.BytesPerSync)
+	opts.SetCompactionStyle(c.CompactionStyle)
+	opts.SetMaxSequentialSkipInIterations(c.MaxSequentialSkipInIterations)
+	opts.SetInplaceUpdateSupport(c.InplaceUpdateSupport)
+	opts.SetInplaceUpdateNumLocks(c.InplaceUpdateNumLocks)
+	opts.SetBloomLocality(c.BloomLocality)
+	opts.SetMaxSuccessiveMerges(c.MaxSuccessiveMerges)
+
+	var built []interface{ Destroy() }
+	if bc := c.BlockBasedTable; bc != nil {
+		bbto := NewDefaultBlockBasedTableOptions()
+		built = append(built, bbto)
+		bbto.SetBlockSize(bc.BlockSize)
+		bbto.SetBlockSizeDeviation(bc.BlockSizeDeviation)
+		bbto.SetBlockRestartInterval(bc.BlockRestartInterval)
+		bbto.SetNoBlockCache(bc.NoBlockCache)
+		if !bc.NoBlockCache && bc.BlockCacheSize > 0 {
+			cache := NewLRUCache(bc.BlockCacheSize)
+			built = append(built, cache)
+			bbto.SetBlockCache(cache)
+		}
+		bbto.SetWholeKeyFiltering(bc.WholeKeyFiltering)
+		opts.SetBlockBasedTableFactory(bbto)
+	}
+	if uc := c.UniversalCompaction; uc != nil {
+		uco := NewDefaultUniversalCompactionOptions()
+		built = append(built, uco)
+		uco.SetSizeRatio(uc.SizeRatio)
+		uco.SetMinMergeWidth(uc.MinMergeWidth)
+		uco.SetMaxMergeWidth(uc.MaxMergeWidth)
+		uco.SetMaxSizeAmplificationPercent(uc.MaxSizeAmplificationPercent)
+		uco.SetCompressionSizePercent(uc.CompressionSizePercent)
+		uco.SetStopStyle(uc.StopStyle)
+		opts.SetUniversalCompactionOptions(uco)
+	}
+	if fc := c.FIFOCompaction; fc != nil {
+		fco := NewDefaultFIFOCompactionOptions()
+		built = append(built, fco)
+		fco.SetMaxTableFilesSize(fc.MaxTableFilesSize)
+		opts.SetFIFOCompactionOptions(fco)
+	}
+	if len(built) > 0 {
+		opts.cbuilt = newCRef(func() {
+			for _, o := range built {
+				o.Destroy()
+			}
+		})
+	}
+	return opts, nil
+}
+
+// validate checks the enum values of the Config, which RocksDB
+// doesn't check itself.
+func (c *Config) validate() error {
+	if !validCompression(c.Compression) {
+		return invalidConfigValue("compression", c.Compression)
+	}
+	if c.BottommostCompression != nil && !validCompression(*c.BottommostCompression) {
+		return invalidConfigValue("bottommost_compression", *c.BottommostCompression)
+	}
+	switch c.CompactionStyle {
+	case LevelCompactionStyle, UniversalCompactionStyle, FIFOCompactionStyle:
+	default:
+		return invalidConfigValue("compaction_style", c.CompactionStyle)
+	}
+	if c.InfoLogLevel > FatalInfoLogLevel {
+		return invalidConfigValue("info_log_level", c.InfoLogLevel)
+	}
+	if uc := c.UniversalCompaction; uc != nil {
+		switch uc.StopStyle {
+		case CompactionStopStyleSimilarSize, CompactionStopStyleTotalSize:
+		default:
+			return invalidConfigValue("universal_compaction.stop_style", uc.StopStyle)
+		}
+	}
+	return nil
+}
+
+func validCompression(value CompressionType) bool {
+	switch value {
+	case NoCompression, SnappyCompression, ZLibCompression, Bz2Compression, LZ4Compression, LZ4HCCompression, XpressCompression, ZSTDCompression:
+		return true
+	}
+	return false
+}
+
+func invalidConfigValue(name string, value interface{}) error {
+	return &OptionError{
+		Cause:   ErrInvalidOptionValue,
+		Message: fmt.Sprintf("invalid value %v for %s", value, name),
+	}
+}
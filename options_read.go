@@ -1,5 +1,6 @@
 package gorocksdb
 
+// #include <stdlib.h>
 // #include "rocksdb/c.h"
 import "C"
 import "unsafe"
@@ -24,6 +25,16 @@ const (
 // database.
 type ReadOptions struct {
 	c *C.rocksdb_readoptions_t
+
+	// Hold references for GC.
+	snap *Snapshot
+
+	// RocksDB keeps pointers to the timestamps, so they are copied to
+	// C memory which is freed by Destroy.
+	timestamp    []byte
+	cTimestamp   unsafe.Pointer
+	iterStartTs  []byte
+	cIterStartTs unsafe.Pointer
 }
 
 // NewDefaultReadOptions creates a default ReadOptions object.
@@ -33,7 +44,7 @@ func NewDefaultReadOptions() *ReadOptions {
 
 // NewNativeReadOptions creates a ReadOptions object.
 func NewNativeReadOptions(c *C.rocksdb_readoptions_t) *ReadOptions {
-	return &ReadOptions{c}
+	return &ReadOptions{c: c}
 }
 
 // UnsafeGetReadOptions returns the underlying c read options object.
@@ -48,6 +59,12 @@ func (opts *ReadOptions) SetVerifyChecksums(value bool) {
 	C.rocksdb_readoptions_set_verify_checksums(opts.c, boolToChar(value))
 }
 
+// GetVerifyChecksums returns whether data read from storage is
+// verified against its checksums.
+func (opts *ReadOptions) GetVerifyChecksums() bool {
+	return charToBool(C.rocksdb_readoptions_get_verify_checksums(opts.c))
+}
+
 // SetFillCache specify whether the "data block"/"index block"/"filter block"
 // read for this iteration should be cached in memory?
 // Callers may wish to set this field to false for bulk scans.
@@ -56,14 +73,25 @@ func (opts *ReadOptions) SetFillCache(value bool) {
 	C.rocksdb_readoptions_set_fill_cache(opts.c, boolToChar(value))
 }
 
+// GetFillCache returns whether data read is cached in memory.
+func (opts *ReadOptions) GetFillCache() bool {
+	return charToBool(C.rocksdb_readoptions_get_fill_cache(opts.c))
+}
+
 // SetSnapshot sets the snapshot which should be used for the read.
 // The snapshot must belong to the DB that is being read and must
 // not have been released.
 // Default: nil
 func (opts *ReadOptions) SetSnapshot(snap *Snapshot) {
+	opts.snap = snap
 	C.rocksdb_readoptions_set_snapshot(opts.c, snap.c)
 }
 
+// GetSnapshot returns the snapshot to read from, nil if none was set.
+func (opts *ReadOptions) GetSnapshot() *Snapshot {
+	return opts.snap
+}
+
 // SetReadTier specify if this read request should process data that ALREADY
 // resides on a particular cache. If the required data is not
 // found at the specified cache, then Status::Incomplete is returned.
@@ -72,6 +100,11 @@ func (opts *ReadOptions) SetReadTier(value ReadTier) {
 	C.rocksdb_readoptions_set_read_tier(opts.c, C.int(value))
 }
 
+// GetReadTier returns the read tier.
+func (opts *ReadOptions) GetReadTier() ReadTier {
+	return ReadTier(C.rocksdb_readoptions_get_read_tier(opts.c))
+}
+
 // SetTailing specify if to create a tailing iterator.
 // A special iterator that has a view of the complete database
 // (i.e. it can also be used to read newly added data) and
@@ -82,8 +115,59 @@ func (opts *ReadOptions) SetTailing(value bool) {
 	C.rocksdb_readoptions_set_tailing(opts.c, boolToChar(value))
 }
 
+// GetTailing returns whether a tailing iterator is created.
+func (opts *ReadOptions) GetTailing() bool {
+	return charToBool(C.rocksdb_readoptions_get_tailing(opts.c))
+}
+
+// SetTimestamp sets the timestamp to read at. Only entries with a
+// timestamp less than or equal to it are visible. The database must use a
+// TimestampComparator.
+// Default: nil
+func (opts *ReadOptions) SetTimestamp(ts []byte) {
+	cTs := copyTimestamp(ts)
+	C.rocksdb_readoptions_set_timestamp(opts.c, (*C.char)(cTs), C.size_t(len(ts)))
+	C.free(opts.cTimestamp)
+	opts.timestamp, opts.cTimestamp = ts, cTs
+}
+
+// GetTimestamp returns the timestamp to read at, nil if none was set.
+func (opts *ReadOptions) GetTimestamp() []byte {
+	return opts.timestamp
+}
+
+// SetIterStartTimestamp sets the lower bound of the timestamps of the
+// entries returned by an iterator. If set, iterators return all versions
+// of a key with a timestamp in [iter start timestamp, timestamp].
+// Default: nil
+func (opts *ReadOptions) SetIterStartTimestamp(ts []byte) {
+	cTs := copyTimestamp(ts)
+	C.rocksdb_readoptions_set_iter_start_ts(opts.c, (*C.char)(cTs), C.size_t(len(ts)))
+	C.free(opts.cIterStartTs)
+	opts.iterStartTs, opts.cIterStartTs = ts, cTs
+}
+
+// GetIterStartTimestamp returns the lower bound of the timestamps of the
+// entries returned by an iterator, nil if none was set.
+func (opts *ReadOptions) GetIterStartTimestamp() []byte {
+	return opts.iterStartTs
+}
+
+// copyTimestamp copies ts to C memory, nil is kept.
+func copyTimestamp(ts []byte) unsafe.Pointer {
+	if ts == nil {
+		return nil
+	}
+	return C.CBytes(ts)
+}
+
 // Destroy deallocates the ReadOptions object.
 func (opts *ReadOptions) Destroy() {
 	C.rocksdb_readoptions_destroy(opts.c)
+	C.free(opts.cTimestamp)
+	C.free(opts.cIterStartTs)
 	opts.c = nil
+	opts.snap = nil
+	opts.timestamp, opts.cTimestamp = nil, nil
+	opts.iterStartTs, opts.cIterStartTs = nil, nil
 }
@@ -0,0 +1,309 @@
+package gorocksdb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestNewOptionsFromString(t *testing.T) {
+	opts, err := NewOptionsFromString(nil, "write_buffer_size=64M;max_write_buffer_number=4;create_if_missing=true")
+	ensure.Nil(t, err)
+	defer opts.Destroy()
+
+	ensure.Nil(t, opts.SetBlockBasedTableFactoryFromString("block_size=16K;cache_index_and_filter_blocks=true"))
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestNewOptionsFromString")
+	ensure.Nil(t, err)
+	db, err := OpenDb(opts, dir)
+	ensure.Nil(t, err)
+	db.Close()
+
+	_, err = NewOptionsFromString(opts, "write_buffer_size=lots")
+	optErr, ok := err.(*OptionError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, optErr.Cause, ErrInvalidOptionValue)
+
+	optErr, ok = opts.SetBlockBasedTableFactoryFromString("no_such_option=1").(*OptionError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, optErr.Cause, ErrUnknownOption)
+}
+
+func TestNewOptionsFromStringGetters(t *testing.T) {
+	base := NewDefaultOptions()
+	defer base.Destroy()
+	base.SetWalDir("/wal/base")
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	base.SetBlockBasedTableFactory(bbto)
+
+	opts, err := NewOptionsFromString(base, "write_buffer_size=64M;wal_dir=/wal/new")
+	ensure.Nil(t, err)
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 64<<20)
+	ensure.DeepEqual(t, opts.GetWalDir(), "/wal/base")
+	ensure.True(t, opts.GetBlockBasedTableFactory() == bbto)
+
+	ensure.Nil(t, base.SetBlockBasedTableFactoryFromString("block_size=16K"))
+	ensure.True(t, base.GetBlockBasedTableFactory() == nil)
+}
+
+func TestLoadLatestOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gorocksdb-TestLoadLatestOptions")
+	ensure.Nil(t, err)
+
+	givenNames := []string{"default", "guide"}
+	opts := NewDefaultOptions()
+	opts.SetCreateIfMissingColumnFamilies(true)
+	opts.SetCreateIfMissing(true)
+	opts.SetWriteBufferSize(32 << 20)
+	db, cfh, err := OpenDbColumnFamilies(opts, dir, givenNames, []*Options{opts, opts})
+	ensure.Nil(t, err)
+	cfh[0].Destroy()
+	cfh[1].Destroy()
+	db.Close()
+
+	dbOpts, cfNames, cfOpts, err := LoadLatestOptions(dir)
+	ensure.Nil(t, err)
+	defer dbOpts.Destroy()
+	for _, o := range cfOpts {
+		defer o.Destroy()
+	}
+	ensure.SameElements(t, cfNames, givenNames)
+	for _, o := range cfOpts {
+		ensure.DeepEqual(t, o.GetWriteBufferSize(), 32<<20)
+	}
+
+	db, cfh, err = OpenDbColumnFamilies(dbOpts, dir, cfNames, cfOpts)
+	ensure.Nil(t, err)
+	defer db.Close()
+	cfh[0].Destroy()
+	cfh[1].Destroy()
+
+	_, _, _, err = LoadLatestOptions(dir + "-missing")
+	ensure.NotNil(t, err)
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	config := NewDefaultConfig()
+	config.CreateIfMissing = true
+	config.WriteBufferSize = 32 << 20
+	config.Compression = LZ4Compression
+	config.CompressionOptions.Level = 3
+	config.MaxBytesForLevelMultiplier = 8
+	config.CompactionStyle = UniversalCompactionStyle
+	config.BlockBasedTable = &BlockBasedTableConfig{
+		BlockSize:            16 << 10,
+		BlockSizeDeviation:   10,
+		BlockRestartInterval: 8,
+		BlockCacheSize:       64 << 20,
+		WholeKeyFiltering:    true,
+	}
+	config.UniversalCompaction = &UniversalCompactionConfig{
+		SizeRatio:                   2,
+		MinMergeWidth:               4,
+		MaxMergeWidth:               16,
+		MaxSizeAmplificationPercent: 150,
+		CompressionSizePercent:      -1,
+		StopStyle:                   CompactionStopStyleTotalSize,
+	}
+
+	data, err := json.Marshal(config)
+	ensure.Nil(t, err)
+	var decoded Config
+	ensure.Nil(t, json.Unmarshal(data, &decoded))
+	ensure.DeepEqual(t, &decoded, config)
+
+	opts, err := decoded.Build()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, FromOptions(opts), config)
+
+	// the objects created by Build live as long as a clone uses them
+	cloned := opts.Clone()
+	defer cloned.Destroy()
+	opts.Destroy()
+	ensure.DeepEqual(t, FromOptions(cloned), config)
+
+	decoded.Compression = CompressionType(255)
+	_, err = decoded.Build()
+	optErr, ok := err.(*OptionError)
+	ensure.True(t, ok)
+	ensure.DeepEqual(t, optErr.Cause, ErrInvalidOptionValue)
+}
+
+func TestConfigBottommostCompression(t *testing.T) {
+	// a document without the key keeps the bottommost level compressed
+	var config Config
+	ensure.Nil(t, json.Unmarshal([]byte(`{"compression": 1}`), &config))
+	ensure.True(t, config.BottommostCompression == nil)
+	opts, err := config.Build()
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, opts.GetBottommostCompression(), DisableCompressionOption)
+	opts.Destroy()
+
+	zstd := ZSTDCompression
+	config.BottommostCompression = &zstd
+	opts, err = config.Build()
+	ensure.Nil(t, err)
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetBottommostCompression(), ZSTDCompression)
+	ensure.DeepEqual(t, FromOptions(opts).BottommostCompression, &zstd)
+}
+
+func TestOptionsGetters(t *testing.T) {
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+
+	opts.OptimizeLevelStyleCompaction(512 << 20)
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 128<<20)
+	ensure.DeepEqual(t, opts.GetMinWriteBufferNumberToMerge(), 2)
+	ensure.DeepEqual(t, opts.GetMaxBytesForLevelBase(), uint64(512<<20))
+
+	opts.PrepareForBulkLoad()
+	ensure.True(t, opts.GetDisableAutoCompactions())
+	ensure.DeepEqual(t, opts.GetNumLevels(), 2)
+	ensure.DeepEqual(t, opts.GetLevel0FileNumCompactionTrigger(), 1<<30)
+
+	opts.SetCompression(LZ4Compression)
+	ensure.DeepEqual(t, opts.GetCompression(), LZ4Compression)
+	opts.SetCompactionStyle(UniversalCompactionStyle)
+	ensure.DeepEqual(t, opts.GetCompactionStyle(), UniversalCompactionStyle)
+	opts.SetWalDir("/tmp/wal")
+	ensure.DeepEqual(t, opts.GetWalDir(), "/tmp/wal")
+
+	bbto := NewDefaultBlockBasedTableOptions()
+	defer bbto.Destroy()
+	ensure.DeepEqual(t, bbto.GetBlockSize(), 4<<10)
+	bbto.SetBlockSize(16 << 10)
+	ensure.DeepEqual(t, bbto.GetBlockSize(), 16<<10)
+	opts.SetBlockBasedTableFactory(bbto)
+	ensure.DeepEqual(t, opts.GetBlockBasedTableFactory(), bbto)
+	opts.OptimizeForPointLookup(8)
+	ensure.True(t, opts.GetBlockBasedTableFactory() == nil)
+
+	uco := NewDefaultUniversalCompactionOptions()
+	defer uco.Destroy()
+	opts.SetUniversalCompactionOptions(uco)
+	ensure.True(t, opts.GetUniversalCompactionOptions() == uco)
+	opts.OptimizeUniversalStyleCompaction(512 << 20)
+	ensure.True(t, opts.GetUniversalCompactionOptions() == nil)
+
+	strOpts, err := NewOptionsFromString(opts, "compression_opts={zstd_max_train_bytes=4096}")
+	ensure.Nil(t, err)
+	defer strOpts.Destroy()
+	ensure.DeepEqual(t, strOpts.GetCompressionOptions().ZstdMaxTrainBytes, 4096)
+
+	ro := NewDefaultReadOptions()
+	defer ro.Destroy()
+	ensure.True(t, ro.GetFillCache())
+	ro.SetFillCache(false)
+	ensure.False(t, ro.GetFillCache())
+	ro.SetReadTier(BlockCacheTier)
+	ensure.DeepEqual(t, ro.GetReadTier(), BlockCacheTier)
+
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.False(t, wo.GetSync())
+	wo.SetSync(true)
+	ensure.True(t, wo.GetSync())
+	wo.DisableWAL(true)
+	ensure.True(t, wo.GetDisableWAL())
+}
+
+func TestBlockBasedTableIndexOptions(t *testing.T) {
+	bbto := NewDefaultBlockBasedTableOptions()
+	ensure.DeepEqual(t, bbto.GetIndexType(), KBinarySearchIndexType)
+	ensure.True(t, bbto.GetPinTopLevelIndexAndFilter())
+	bbto.SetIndexType(KTwoLevelIndexSearchIndexType)
+	bbto.SetPartitionFilters(true)
+	bbto.SetMetadataBlockSize(8 << 10)
+	bbto.SetCacheIndexAndFilterBlocks(true)
+	bbto.SetCacheIndexAndFilterBlocksWithHighPriority(true)
+	bbto.SetPinL0FilterAndIndexBlocksInCache(true)
+	bbto.SetPinTopLevelIndexAndFilter(true)
+	bbto.SetFormatVersion(5)
+	bbto.SetDataBlockIndexType(KDataBlockIndexTypeBinarySearchAndHash)
+	bbto.SetDataBlockHashRatio(0.5)
+	bbto.SetFilterPolicy(NewBloomFilter(10))
+	ensure.DeepEqual(t, bbto.GetIndexType(), KTwoLevelIndexSearchIndexType)
+	ensure.True(t, bbto.GetPartitionFilters())
+	ensure.DeepEqual(t, bbto.GetMetadataBlockSize(), uint64(8<<10))
+	ensure.True(t, bbto.GetCacheIndexAndFilterBlocks())
+	ensure.True(t, bbto.GetPinL0FilterAndIndexBlocksInCache())
+	ensure.DeepEqual(t, bbto.GetDataBlockIndexType(), KDataBlockIndexTypeBinarySearchAndHash)
+	ensure.DeepEqual(t, bbto.GetDataBlockHashRatio(), 0.5)
+
+	db := newTestDB(t, "TestBlockBasedTableIndexOptions", func(opts *Options) {
+		opts.SetBlockBasedTableFactory(bbto)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	for i := 0; i < 1000; i++ {
+		ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("key%04d", i)), []byte("val")))
+	}
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	ro := NewDefaultReadOptions()
+	v, err := db.Get(ro, []byte("key0500"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	ensure.DeepEqual(t, v.Data(), []byte("val"))
+}
+
+func TestCompressionOptions(t *testing.T) {
+	supported := SupportedCompressions()
+	ensure.DeepEqual(t, supported[0], NoCompression)
+
+	opts := NewDefaultOptions()
+	defer opts.Destroy()
+	ensure.DeepEqual(t, opts.GetBottommostCompression(), DisableCompressionOption)
+	ensure.True(t, opts.GetBottommostCompressionOptions() == nil)
+
+	bottommost := NewCompressionOptions(-14, 19, 0, 16<<10)
+	bottommost.ZstdMaxTrainBytes = 100 << 10
+	opts.SetBottommostCompressionOptions(bottommost)
+	ensure.DeepEqual(t, opts.GetBottommostCompressionOptions(), bottommost)
+
+	// every supported compression can be used for all levels
+	for _, value := range supported {
+		db := newTestDB(t, fmt.Sprintf("TestCompressionOptions%d", value), func(opts *Options) {
+			opts.SetCompression(value)
+			opts.SetBottommostCompression(value)
+			opts.SetBottommostCompressionOptions(bottommost)
+		})
+		wo := NewDefaultWriteOptions()
+		ensure.Nil(t, db.Put(wo, []byte("foo"), bytes.Repeat([]byte("bar"), 1000)))
+		ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+		db.CompactRange(Range{nil, nil})
+		wo.Destroy()
+		db.Close()
+	}
+}
+
+func TestOptionsClone(t *testing.T) {
+	opts := NewDefaultOptions()
+	opts.SetWriteBufferSize(32 << 20)
+	opts.SetComparator(&bytesReverseComparator{})
+
+	cloned := opts.Clone()
+	defer cloned.Destroy()
+	ensure.DeepEqual(t, cloned.GetWriteBufferSize(), 32<<20)
+	ensure.DeepEqual(t, cloned.GetComparator(), opts.GetComparator())
+
+	cloned.SetWriteBufferSize(16 << 20)
+	ensure.DeepEqual(t, opts.GetWriteBufferSize(), 32<<20)
+
+	// the clone keeps the comparator alive
+	opts.Destroy()
+	cloned.SetCreateIfMissing(true)
+	dir, err := ioutil.TempDir("", "gorocksdb-TestOptionsClone")
+	ensure.Nil(t, err)
+	db, err := OpenDb(cloned, dir)
+	ensure.Nil(t, err)
+	db.Close()
+}
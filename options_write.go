@@ -27,6 +27,12 @@ func (opts *WriteOptions) SetSync(value bool) {
 	C.rocksdb_writeoptions_set_sync(opts.c, boolToChar(value))
 }
 
+// GetSync returns whether writes are flushed from the OS buffer cache
+// before they are considered complete.
+func (opts *WriteOptions) GetSync() bool {
+	return charToBool(C.rocksdb_writeoptions_get_sync(opts.c))
+}
+
 // DisableWAL sets whether WAL should be active or not.
 // If true, writes will not first go to the write ahead log,
 // and the write may got lost after a crash.
@@ -35,6 +41,66 @@ func (opts *WriteOptions) DisableWAL(value bool) {
 	C.rocksdb_writeoptions_disable_WAL(opts.c, C.int(btoi(value)))
 }
 
+// GetDisableWAL returns whether writes skip the write ahead log.
+func (opts *WriteOptions) GetDisableWAL() bool {
+	return charToBool(C.rocksdb_writeoptions_get_disable_WAL(opts.c))
+}
+
+// SetIgnoreMissingColumnFamilies sets whether writes to column families
+// which do not exist, e.g. because they were dropped, are ignored. If
+// false, such writes fail.
+// Default: false
+func (opts *WriteOptions) SetIgnoreMissingColumnFamilies(value bool) {
+	C.rocksdb_writeoptions_set_ignore_missing_column_families(opts.c, boolToChar(value))
+}
+
+// GetIgnoreMissingColumnFamilies returns whether writes to missing column
+// families are ignored.
+func (opts *WriteOptions) GetIgnoreMissingColumnFamilies() bool {
+	return charToBool(C.rocksdb_writeoptions_get_ignore_missing_column_families(opts.c))
+}
+
+// SetNoSlowdown sets whether writes fail with an Incomplete error instead
+// of waiting while the database stalls or slows down writes.
+// Default: false
+func (opts *WriteOptions) SetNoSlowdown(value bool) {
+	C.rocksdb_writeoptions_set_no_slowdown(opts.c, boolToChar(value))
+}
+
+// GetNoSlowdown returns whether writes fail instead of waiting for a
+// stall.
+func (opts *WriteOptions) GetNoSlowdown() bool {
+	return charToBool(C.rocksdb_writeoptions_get_no_slowdown(opts.c))
+}
+
+// SetLowPri sets whether writes are low priority. Low priority writes are
+// slowed down while compactions fall behind, so that they do not delay
+// high priority writes, e.g. for batch jobs.
+// Default: false
+func (opts *WriteOptions) SetLowPri(value bool) {
+	C.rocksdb_writeoptions_set_low_pri(opts.c, boolToChar(value))
+}
+
+// GetLowPri returns whether writes are low priority.
+func (opts *WriteOptions) GetLowPri() bool {
+	return charToBool(C.rocksdb_writeoptions_get_low_pri(opts.c))
+}
+
+// SetMemtableInsertHintPerBatch sets whether the keys of a WriteBatch use
+// the position of the previous key as hint for their memtable insert.
+// This speeds up batches of sequential keys, but slows down other batches.
+// It has no effect with concurrent memtable writes.
+// Default: false
+func (opts *WriteOptions) SetMemtableInsertHintPerBatch(value bool) {
+	C.rocksdb_writeoptions_set_memtable_insert_hint_per_batch(opts.c, boolToChar(value))
+}
+
+// GetMemtableInsertHintPerBatch returns whether the keys of a WriteBatch
+// use insert hints.
+func (opts *WriteOptions) GetMemtableInsertHintPerBatch() bool {
+	return charToBool(C.rocksdb_writeoptions_get_memtable_insert_hint_per_batch(opts.c))
+}
+
 // Destroy deallocates the WriteOptions object.
 func (opts *WriteOptions) Destroy() {
 	C.rocksdb_writeoptions_destroy(opts.c)
@@ -0,0 +1,19 @@
+package gorocksdb
+
+// Names of database properties, see DB.GetProperty and DB.GetIntProperty.
+const (
+	// PropertyNumBlobFiles is the number of blob files.
+	PropertyNumBlobFiles = "rocksdb.num-blob-files"
+	// PropertyBlobStats is a string with the number, the total size and the
+	// garbage of the blob files.
+	PropertyBlobStats = "rocksdb.blob-stats"
+	// PropertyTotalBlobFileSize is the total size of all blob files,
+	// including those of old versions.
+	PropertyTotalBlobFileSize = "rocksdb.total-blob-file-size"
+	// PropertyLiveBlobFileSize is the total size of the blob files of the
+	// current version.
+	PropertyLiveBlobFileSize = "rocksdb.live-blob-file-size"
+	// PropertyLiveBlobFileGarbageSize is the size of the garbage in the blob
+	// files of the current version.
+	PropertyLiveBlobFileGarbageSize = "rocksdb.live-blob-file-garbage-size"
+)
@@ -0,0 +1,50 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// RateLimiter limits the rate of the background I/O of flushes and
+// compactions, see Options.SetRateLimiter. It can be shared by several
+// databases, which then share its rate.
+//
+// The C API of RocksDB can neither change the rate of a RateLimiter after
+// it was created nor select the mode; writes are limited.
+type RateLimiter struct {
+	c *C.rocksdb_ratelimiter_t
+}
+
+// NewRateLimiter creates a RateLimiter.
+//
+// bytesPerSec is the rate limit. refillPeriodUs is the period in
+// microseconds in which the tokens are refilled; a lower value smooths the
+// I/O but costs more CPU. fairness is the chance 1/fairness with which
+// low priority requests are served before high priority ones, so that they
+// are not starved.
+func NewRateLimiter(bytesPerSec, refillPeriodUs int64, fairness int32) *RateLimiter {
+	return NewNativeRateLimiter(C.rocksdb_ratelimiter_create(C.int64_t(bytesPerSec), C.int64_t(refillPeriodUs), C.int32_t(fairness)))
+}
+
+// NewAutoTunedRateLimiter creates a RateLimiter which adjusts the rate to
+// the demand, with bytesPerSec as upper bound. See NewRateLimiter for the
+// other parameters.
+func NewAutoTunedRateLimiter(bytesPerSec, refillPeriodUs int64, fairness int32) *RateLimiter {
+	return NewNativeRateLimiter(C.rocksdb_ratelimiter_create_auto_tuned(C.int64_t(bytesPerSec), C.int64_t(refillPeriodUs), C.int32_t(fairness)))
+}
+
+// NewDefaultRateLimiter creates a RateLimiter with the given rate, a
+// refill period of 100ms and a fairness of 10.
+func NewDefaultRateLimiter(bytesPerSec int64) *RateLimiter {
+	return NewRateLimiter(bytesPerSec, 100*1000, 10)
+}
+
+// NewNativeRateLimiter creates a RateLimiter object.
+func NewNativeRateLimiter(c *C.rocksdb_ratelimiter_t) *RateLimiter {
+	return &RateLimiter{c}
+}
+
+// Destroy deallocates the RateLimiter object. Databases which use it keep
+// their own reference.
+func (rl *RateLimiter) Destroy() {
+	C.rocksdb_ratelimiter_destroy(rl.c)
+	rl.c = nil
+}
@@ -0,0 +1,70 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestRateLimiter(t *testing.T) {
+	rl := NewDefaultRateLimiter(1 << 20)
+	defer rl.Destroy()
+
+	db := newTestDB(t, "TestRateLimiter", func(opts *Options) {
+		opts.SetRateLimiter(rl)
+		ensure.DeepEqual(t, opts.GetRateLimiter(), rl)
+	})
+	defer db.Close()
+
+	// random values don't compress, so the flush writes about 512 KB
+	wo := NewDefaultWriteOptions()
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 4; i++ {
+		val := make([]byte, 128<<10)
+		rnd.Read(val)
+		ensure.Nil(t, db.Put(wo, []byte(fmt.Sprintf("foo%d", i)), val))
+	}
+	start := time.Now()
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+	elapsed := time.Since(start)
+
+	// At 1 MB/s the flush needs about 400ms after the first refill period
+	// of 100 KB. Only half of that is required, which is still far more
+	// than the few milliseconds an unlimited flush takes.
+	ensure.True(t, elapsed >= 200*time.Millisecond, elapsed)
+
+	ro := NewDefaultReadOptions()
+	v, err := db.Get(ro, []byte("foo0"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	ensure.DeepEqual(t, v.Size(), 128<<10)
+}
+
+func TestBackupEngineRateLimit(t *testing.T) {
+	db := newTestDB(t, "TestBackupEngineRateLimit", nil)
+	defer db.Close()
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("foo"), []byte("bar")))
+
+	dir, err := ioutil.TempDir("", "gorocksdb-TestBackupEngineRateLimit")
+	ensure.Nil(t, err)
+	opts := NewBackupEngineOptions(dir)
+	defer opts.Destroy()
+	opts.SetBackupRateLimit(8 << 20)
+	opts.SetRestoreRateLimit(8 << 20)
+	ensure.DeepEqual(t, opts.GetBackupRateLimit(), uint64(8<<20))
+	ensure.DeepEqual(t, opts.GetRestoreRateLimit(), uint64(8<<20))
+
+	be, err := OpenBackupEngineWithOptions(opts, nil)
+	ensure.Nil(t, err)
+	defer be.Close()
+	ensure.Nil(t, be.CreateNewBackup(db))
+
+	info := be.GetInfo()
+	defer info.Destroy()
+	ensure.DeepEqual(t, info.GetCount(), 1)
+}
@@ -0,0 +1,60 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+import "sync"
+
+// handleTable holds references to Go values which are passed to C by
+// handle, e.g. comparators and merge operators. It is safe for concurrent use.
+type handleTable struct {
+	mu     sync.RWMutex
+	next   uintptr
+	values map[uintptr]interface{}
+}
+
+// handles holds all Go callbacks given to RocksDB.
+var handles = newHandleTable()
+
+func newHandleTable() *handleTable {
+	return &handleTable{values: make(map[uintptr]interface{})}
+}
+
+// register adds a value and returns its handle.
+func (t *handleTable) register(value interface{}) uintptr {
+	t.mu.Lock()
+	t.next++
+	idx := t.next
+	t.values[idx] = value
+	t.mu.Unlock()
+	return idx
+}
+
+// get returns the value of the given handle.
+func (t *handleTable) get(idx uintptr) interface{} {
+	t.mu.RLock()
+	value := t.values[idx]
+	t.mu.RUnlock()
+	return value
+}
+
+// release removes the value of the given handle.
+func (t *handleTable) release(idx uintptr) {
+	t.mu.Lock()
+	delete(t.values, idx)
+	t.mu.Unlock()
+}
+
+// len returns the number of registered values.
+func (t *handleTable) len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.values)
+}
+
+// RocksDB calls the destructor when it frees a C object which was created
+// for a Go callback, see gorocksdb_destruct_handler.
+
+//export gorocksdb_destruct
+func gorocksdb_destruct(idx C.uintptr_t) {
+	handles.release(uintptr(idx))
+}
@@ -0,0 +1,68 @@
+package gorocksdb
+
+import (
+	"io/ioutil"
+	"sync"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestHandleTableConcurrent(t *testing.T) {
+	table := newHandleTable()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				idx := table.register(i)
+				if table.get(idx) != i {
+					t.Errorf("got %v for handle %d, want %d", table.get(idx), idx, i)
+				}
+				table.release(idx)
+			}
+		}(i)
+	}
+	wg.Wait()
+	ensure.DeepEqual(t, table.len(), 0)
+}
+
+func TestCallbacksReleased(t *testing.T) {
+	before := handles.len()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			dir, err := ioutil.TempDir("", "gorocksdb-TestCallbacksReleased")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+
+			opts := NewDefaultOptions()
+			opts.SetCreateIfMissing(true)
+			opts.SetComparator(&bytesReverseComparator{})
+			opts.SetMergeOperator(&mockMergeOperator{})
+			opts.SetCompactionFilter(&mockCompactionFilter{})
+			db, err := OpenDb(opts, dir)
+			opts.Destroy()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer db.Close()
+
+			wo := NewDefaultWriteOptions()
+			defer wo.Destroy()
+			if err := db.Put(wo, []byte("foo"), []byte("bar")); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	ensure.DeepEqual(t, handles.len(), before)
+}
@@ -1,7 +1,12 @@
 package gorocksdb
 
 // #include "rocksdb/c.h"
+// #include "gorocksdb.h"
 import "C"
+import (
+	"bytes"
+	"strconv"
+)
 
 // A SliceTransform can be used as a prefix extractor.
 type SliceTransform interface {
@@ -19,36 +24,135 @@ type SliceTransform interface {
 }
 
 // NewFixedPrefixTransform creates a new fixed prefix transform.
+// Keys shorter than prefixLen are not in its domain.
 func NewFixedPrefixTransform(prefixLen int) SliceTransform {
-	return NewNativeSliceTransform(C.rocksdb_slicetransform_create_fixed_prefix(C.size_t(prefixLen)))
+	return nativeSliceTransform{
+		c:  C.rocksdb_slicetransform_create_fixed_prefix(C.size_t(prefixLen)),
+		st: fixedPrefixTransform(prefixLen),
+	}
+}
+
+// NewCappedPrefixTransform creates a new capped prefix transform. The
+// prefix of a key is its first capLen bytes, or the whole key if it is
+// shorter. It is implemented in C like RocksDB's NewCappedPrefixTransform,
+// which is not part of the C API.
+func NewCappedPrefixTransform(capLen int) SliceTransform {
+	return nativeSliceTransform{
+		c:  C.gorocksdb_slicetransform_create_capped_prefix(C.size_t(capLen)),
+		st: cappedPrefixTransform(capLen),
+	}
+}
+
+// NewNoopPrefixTransform creates a new transform which uses the whole key
+// as prefix.
+func NewNoopPrefixTransform() SliceTransform {
+	return nativeSliceTransform{
+		c:  C.rocksdb_slicetransform_create_noop(),
+		st: noopPrefixTransform{},
+	}
 }
 
 // NewNativeSliceTransform creates a SliceTransform object.
 func NewNativeSliceTransform(c *C.rocksdb_slicetransform_t) SliceTransform {
-	return nativeSliceTransform{c}
+	return nativeSliceTransform{c: c}
 }
 
 type nativeSliceTransform struct {
 	c *C.rocksdb_slicetransform_t
+
+	// Go implementation of the transform, if known.
+	st SliceTransform
+}
+
+func (st nativeSliceTransform) Transform(src []byte) []byte {
+	if st.st == nil {
+		return nil
+	}
+	return st.st.Transform(src)
+}
+
+func (st nativeSliceTransform) InDomain(src []byte) bool {
+	return st.st != nil && st.st.InDomain(src)
+}
+
+func (st nativeSliceTransform) InRange(src []byte) bool {
+	return st.st != nil && st.st.InRange(src)
+}
+
+func (st nativeSliceTransform) Name() string {
+	if st.st == nil {
+		return ""
+	}
+	return st.st.Name()
+}
+
+type fixedPrefixTransform int
+
+func (st fixedPrefixTransform) Transform(src []byte) []byte { return src[:st] }
+func (st fixedPrefixTransform) InDomain(src []byte) bool    { return len(src) >= int(st) }
+func (st fixedPrefixTransform) InRange(src []byte) bool     { return len(src) == int(st) }
+func (st fixedPrefixTransform) Name() string {
+	return "rocksdb.FixedPrefix." + strconv.Itoa(int(st))
 }
 
-func (st nativeSliceTransform) Transform(src []byte) []byte { return nil }
-func (st nativeSliceTransform) InDomain(src []byte) bool    { return false }
-func (st nativeSliceTransform) InRange(src []byte) bool     { return false }
-func (st nativeSliceTransform) Name() string                { return "" }
+type cappedPrefixTransform int
 
-// Hold references to slice transforms.
-var sliceTransforms []SliceTransform
+func (st cappedPrefixTransform) Transform(src []byte) []byte {
+	if len(src) > int(st) {
+		return src[:st]
+	}
+	return src
+}
+func (st cappedPrefixTransform) InDomain(src []byte) bool { return true }
+func (st cappedPrefixTransform) InRange(src []byte) bool  { return len(src) <= int(st) }
+func (st cappedPrefixTransform) Name() string {
+	return "rocksdb.CappedPrefix." + strconv.Itoa(int(st))
+}
+
+type noopPrefixTransform struct{}
+
+func (st noopPrefixTransform) Transform(src []byte) []byte { return src }
+func (st noopPrefixTransform) InDomain(src []byte) bool    { return true }
+func (st noopPrefixTransform) InRange(src []byte) bool     { return true }
+func (st noopPrefixTransform) Name() string                { return "rocksdb.Noop" }
+
+// NewDelimiterPrefixTransform creates a transform whose prefix of a key is
+// everything up to and including the first occurrence of delim, e.g. the
+// tenant ID in keys like "tenant:rest". Keys without delim are not in its
+// domain. It is implemented in Go, so it costs a cgo callback per call.
+func NewDelimiterPrefixTransform(delim []byte) SliceTransform {
+	return &delimiterPrefixTransform{delim: append([]byte{}, delim...)}
+}
+
+type delimiterPrefixTransform struct {
+	delim []byte
+}
+
+func (st *delimiterPrefixTransform) Transform(src []byte) []byte {
+	return src[:bytes.Index(src, st.delim)+len(st.delim)]
+}
+
+func (st *delimiterPrefixTransform) InDomain(src []byte) bool {
+	return bytes.Contains(src, st.delim)
+}
+
+func (st *delimiterPrefixTransform) InRange(src []byte) bool {
+	i := bytes.Index(src, st.delim)
+	return i >= 0 && i+len(st.delim) == len(src)
+}
+
+func (st *delimiterPrefixTransform) Name() string {
+	return "gorocksdb.DelimiterPrefix." + string(st.delim)
+}
 
-func registerSliceTransform(st SliceTransform) int {
-	sliceTransforms = append(sliceTransforms, st)
-	return len(sliceTransforms) - 1
+func registerSliceTransform(st SliceTransform) uintptr {
+	return handles.register(st)
 }
 
 //export gorocksdb_slicetransform_transform
 func gorocksdb_slicetransform_transform(idx int, cKey *C.char, cKeyLen C.size_t, cDstLen *C.size_t) *C.char {
 	key := charToByte(cKey, cKeyLen)
-	dst := sliceTransforms[idx].Transform(key)
+	dst := handles.get(uintptr(idx)).(SliceTransform).Transform(key)
 	*cDstLen = C.size_t(len(dst))
 	return cByteSlice(dst)
 }
@@ -56,18 +160,18 @@ func gorocksdb_slicetransform_transform(idx int, cKey *C.char, cKeyLen C.size_t,
 //export gorocksdb_slicetransform_in_domain
 func gorocksdb_slicetransform_in_domain(idx int, cKey *C.char, cKeyLen C.size_t) C.uchar {
 	key := charToByte(cKey, cKeyLen)
-	inDomain := sliceTransforms[idx].InDomain(key)
+	inDomain := handles.get(uintptr(idx)).(SliceTransform).InDomain(key)
 	return boolToChar(inDomain)
 }
 
 //export gorocksdb_slicetransform_in_range
 func gorocksdb_slicetransform_in_range(idx int, cKey *C.char, cKeyLen C.size_t) C.uchar {
 	key := charToByte(cKey, cKeyLen)
-	inRange := sliceTransforms[idx].InRange(key)
+	inRange := handles.get(uintptr(idx)).(SliceTransform).InRange(key)
 	return boolToChar(inRange)
 }
 
 //export gorocksdb_slicetransform_name
 func gorocksdb_slicetransform_name(idx int) *C.char {
-	return stringToChar(sliceTransforms[idx].Name())
+	return stringToChar(handles.get(uintptr(idx)).(SliceTransform).Name())
 }
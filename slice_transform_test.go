@@ -35,6 +35,85 @@ func TestFixedPrefixTransformOpen(t *testing.T) {
 	defer db.Close()
 }
 
+func TestNativePrefixTransforms(t *testing.T) {
+	capped := NewCappedPrefixTransform(3)
+	ensure.DeepEqual(t, capped.Name(), "rocksdb.CappedPrefix.3")
+	ensure.DeepEqual(t, capped.Transform([]byte("foobar")), []byte("foo"))
+	ensure.DeepEqual(t, capped.Transform([]byte("fo")), []byte("fo"))
+	ensure.True(t, capped.InDomain([]byte("fo")))
+
+	noop := NewNoopPrefixTransform()
+	ensure.DeepEqual(t, noop.Name(), "rocksdb.Noop")
+	ensure.DeepEqual(t, noop.Transform([]byte("foobar")), []byte("foobar"))
+
+	db := newTestDB(t, "TestNativePrefixTransforms", func(opts *Options) {
+		opts.SetPrefixExtractor(capped)
+	})
+	defer db.Close()
+
+	// keys shorter than the prefix are fine
+	wo := NewDefaultWriteOptions()
+	ensure.Nil(t, db.Put(wo, []byte("fo"), []byte("val")))
+	ensure.Nil(t, db.Put(wo, []byte("foo1"), []byte("val")))
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	ro := NewDefaultReadOptions()
+	v, err := db.Get(ro, []byte("fo"))
+	ensure.Nil(t, err)
+	defer v.Free()
+	ensure.DeepEqual(t, v.Data(), []byte("val"))
+}
+
+func TestDelimiterPrefixTransform(t *testing.T) {
+	st := NewDelimiterPrefixTransform([]byte(":"))
+	ensure.DeepEqual(t, st.Transform([]byte("t1:foo:bar")), []byte("t1:"))
+	ensure.True(t, st.InDomain([]byte("t1:foo")))
+	ensure.False(t, st.InDomain([]byte("t1")))
+	ensure.True(t, st.InRange([]byte("t1:")))
+	ensure.False(t, st.InRange([]byte("t1:foo")))
+
+	db := newTestDB(t, "TestDelimiterPrefixTransform", func(opts *Options) {
+		bbto := NewDefaultBlockBasedTableOptions()
+		bbto.SetFilterPolicy(NewBloomFilter(10))
+		bbto.SetWholeKeyFiltering(false)
+		// without a block cache KeyMayExist can only rule out keys by
+		// the filter
+		bbto.SetNoBlockCache(true)
+		opts.SetBlockBasedTableFactory(bbto)
+		opts.SetPrefixExtractor(st)
+	})
+	defer db.Close()
+
+	wo := NewDefaultWriteOptions()
+	for _, k := range []string{"t1:a", "t1:b", "t10:a", "t2:a", "t2:b", "t2:c"} {
+		ensure.Nil(t, db.Put(wo, []byte(k), []byte("val")))
+	}
+	ensure.Nil(t, db.Flush(NewDefaultFlushOptions()))
+
+	// the tenant IDs have different lengths, so a fixed prefix would mix
+	// the keys of t1 and t10
+	ro := NewDefaultReadOptions()
+	for prefix, want := range map[string]int{"t1:": 2, "t10:": 1, "t2:": 3, "t3:": 0} {
+		iter := db.NewIterator(ro)
+		numFound := 0
+		for iter.Seek([]byte(prefix)); iter.ValidForPrefix([]byte(prefix)); iter.Next() {
+			numFound++
+		}
+		ensure.Nil(t, iter.Err())
+		ensure.DeepEqual(t, numFound, want, prefix)
+		iter.Close()
+	}
+
+	// the prefix bloom filter rules out missing tenants, but not missing
+	// keys of existing tenants
+	ensure.False(t, db.KeyMayExist(ro, []byte("t3:a")))
+	ensure.True(t, db.KeyMayExist(ro, []byte("t1:z")))
+	v, err := db.Get(ro, []byte("t3:a"))
+	ensure.Nil(t, err)
+	ensure.DeepEqual(t, v.Size(), 0)
+	v.Free()
+}
+
 type testSliceTransform struct {
 	initiated bool
 }
@@ -0,0 +1,89 @@
+package gorocksdb
+
+// #include "rocksdb/c.h"
+import "C"
+
+// WriteBufferManager limits the total memory used by the memtables of all
+// column families and databases it is attached to, see
+// Options.SetWriteBufferManager. Once the limit is reached, memtables are
+// flushed, and writes stall if stalling is allowed.
+type WriteBufferManager struct {
+	c *C.rocksdb_write_buffer_manager_t
+
+	// Hold references for GC.
+	cache *Cache
+}
+
+// NewWriteBufferManager creates a WriteBufferManager with a budget of
+// bufferSize bytes. A bufferSize of 0 only tracks the memory usage.
+// If allowStall is true, writes stall while the memory usage exceeds the
+// budget until flushes free enough memory.
+func NewWriteBufferManager(bufferSize int, allowStall bool) *WriteBufferManager {
+	return NewNativeWriteBufferManager(C.rocksdb_write_buffer_manager_create(C.size_t(bufferSize), C.bool(allowStall)))
+}
+
+// NewWriteBufferManagerWithCache creates a WriteBufferManager which also
+// charges the memory of the memtables to cache, so that memtables and
+// blocks share the memory budget of the cache.
+func NewWriteBufferManagerWithCache(bufferSize int, cache *Cache, allowStall bool) *WriteBufferManager {
+	wbm := NewNativeWriteBufferManager(C.rocksdb_write_buffer_manager_create_with_cache(C.size_t(bufferSize), cache.c, C.bool(allowStall)))
+	wbm.cache = cache
+	return wbm
+}
+
+// NewNativeWriteBufferManager creates a WriteBufferManager object.
+func NewNativeWriteBufferManager(c *C.rocksdb_write_buffer_manager_t) *WriteBufferManager {
+	return &WriteBufferManager{c: c}
+}
+
+// Enabled returns whether the memory budget is enforced, i.e. the buffer
+// size is not 0.
+func (wbm *WriteBufferManager) Enabled() bool {
+	return bool(C.rocksdb_write_buffer_manager_enabled(wbm.c))
+}
+
+// CostToCache returns whether the memory of the memtables is charged to a
+// cache.
+func (wbm *WriteBufferManager) CostToCache() bool {
+	return bool(C.rocksdb_write_buffer_manager_cost_to_cache(wbm.c))
+}
+
+// GetMemoryUsage returns the memory used by all memtables.
+func (wbm *WriteBufferManager) GetMemoryUsage() int {
+	return int(C.rocksdb_write_buffer_manager_memory_usage(wbm.c))
+}
+
+// GetMutableMemtableMemoryUsage returns the memory used by the memtables
+// which are not being flushed.
+func (wbm *WriteBufferManager) GetMutableMemtableMemoryUsage() int {
+	return int(C.rocksdb_write_buffer_manager_mutable_memtable_memory_usage(wbm.c))
+}
+
+// GetDummyEntriesInCacheUsage returns the memory charged to the cache.
+func (wbm *WriteBufferManager) GetDummyEntriesInCacheUsage() int {
+	return int(C.rocksdb_write_buffer_manager_dummy_entries_in_cache_usage(wbm.c))
+}
+
+// SetBufferSize changes the memory budget.
+func (wbm *WriteBufferManager) SetBufferSize(value int) {
+	C.rocksdb_write_buffer_manager_set_buffer_size(wbm.c, C.size_t(value))
+}
+
+// GetBufferSize returns the memory budget.
+func (wbm *WriteBufferManager) GetBufferSize() int {
+	return int(C.rocksdb_write_buffer_manager_buffer_size(wbm.c))
+}
+
+// SetAllowStall sets whether writes stall while the memory usage exceeds
+// the budget.
+func (wbm *WriteBufferManager) SetAllowStall(value bool) {
+	C.rocksdb_write_buffer_manager_set_allow_stall(wbm.c, C.bool(value))
+}
+
+// Destroy deallocates the WriteBufferManager object. Databases which use
+// it keep their own reference.
+func (wbm *WriteBufferManager) Destroy() {
+	C.rocksdb_write_buffer_manager_destroy(wbm.c)
+	wbm.c = nil
+	wbm.cache = nil
+}
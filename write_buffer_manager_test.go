@@ -0,0 +1,41 @@
+package gorocksdb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/facebookgo/ensure"
+)
+
+func TestWriteBufferManagerShared(t *testing.T) {
+	cache := NewLRUCache(64 << 20)
+	defer cache.Destroy()
+	wbm := NewWriteBufferManagerWithCache(32<<20, cache, false)
+	defer wbm.Destroy()
+	ensure.True(t, wbm.Enabled())
+	ensure.True(t, wbm.CostToCache())
+	ensure.DeepEqual(t, wbm.GetBufferSize(), 32<<20)
+
+	var dbs []*DB
+	for i := 0; i < 2; i++ {
+		db := newTestDB(t, fmt.Sprintf("TestWriteBufferManagerShared%d", i), func(opts *Options) {
+			opts.SetWriteBufferManager(wbm)
+			ensure.DeepEqual(t, opts.GetWriteBufferManager(), wbm)
+		})
+		defer db.Close()
+		dbs = append(dbs, db)
+	}
+
+	// the memtables of both databases are accounted to the manager
+	wo := NewDefaultWriteOptions()
+	defer wo.Destroy()
+	ensure.Nil(t, dbs[0].Put(wo, []byte("foo"), make([]byte, 1<<20)))
+	usage := wbm.GetMemoryUsage()
+	ensure.True(t, usage > 0)
+	ensure.Nil(t, dbs[1].Put(wo, []byte("foo"), make([]byte, 1<<20)))
+	ensure.True(t, wbm.GetMemoryUsage() > usage)
+	ensure.True(t, cache.GetUsage() > 0)
+
+	wbm.SetBufferSize(64 << 20)
+	ensure.DeepEqual(t, wbm.GetBufferSize(), 64<<20)
+}